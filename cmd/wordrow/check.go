@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+	"github.com/ericcornelissen/wordrow/internal/logger"
+)
+
+// Check the content of the `reader` for the input `name` using the `replace`
+// function. The changes that would be made are reported to the `output` using
+// the `report` function, the content itself is never changed. It returns the
+// number of changes that would be made.
+func checkInput(
+	reader fs.Reader,
+	name string,
+	replace replacer,
+	report reporter,
+	output io.Writer,
+) (int, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return 0, errors.Newf("Could not read from '%s'", name)
+	}
+
+	updatedContent, changes := replace(content)
+	if len(changes) > 0 {
+		report(output, name, content, updatedContent, changes)
+	}
+
+	return len(changes), nil
+}
+
+// Opens the file at `filePath` in read-only mode and checks it using the
+// `replace` function, see checkInput.
+func openAndCheckFile(
+	filePath string,
+	replace replacer,
+	report reporter,
+	output io.Writer,
+) (int, error) {
+	logger.Debugf("Opening '%s'", filePath)
+	handle, err := fs.OpenFile(filePath, fs.OReadOnly)
+	if err != nil {
+		return 0, err
+	}
+
+	defer handle.Close()
+
+	logger.Debugf("Checking '%s'", filePath)
+	return checkInput(handle, filePath, replace, report, output)
+}
+
+// Check all files specified by `filePaths` using the `replace` function. The
+// files are checked one after the other so that the `output` is in the same
+// order as `filePaths`. It returns the total number of changes that would be
+// made. Any error that occurs is returned after all files have been checked.
+func checkInputFiles(
+	filePaths []string,
+	replace replacer,
+	report reporter,
+	output io.Writer,
+) (count int, errs []error) {
+	for _, filePath := range filePaths {
+		n, err := openAndCheckFile(filePath, replace, report, output)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		count += n
+	}
+
+	return count, errs
+}
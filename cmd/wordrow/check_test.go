@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+func TestCheckInput(t *testing.T) {
+	mapping := []wordrow.Rule{
+		{From: "hello", To: "hey"},
+		{From: "world", To: "planet"},
+	}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
+	replace := r.Replace
+
+	t.Run("Changes", func(t *testing.T) {
+		content := "Hello world!\nHello\nWorld!"
+		reader := stringsx.NewReader(content)
+		output := new(bytes.Buffer)
+
+		count, err := checkInput(reader, "foo.txt", replace, reportChanges, output)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if count != 4 {
+			t.Errorf("Unexpected number of changes (got %d)", count)
+		}
+
+		expected := `foo.txt:1:1: "Hello" -> "Hey"
+foo.txt:1:7: "world" -> "planet"
+foo.txt:2:1: "Hello" -> "Hey"
+foo.txt:3:1: "World" -> "Planet"
+`
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("No changes", func(t *testing.T) {
+		reader := stringsx.NewReader("foobar")
+		output := new(bytes.Buffer)
+
+		count, err := checkInput(reader, "foo.txt", replace, reportChanges, output)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if count != 0 {
+			t.Errorf("Unexpected number of changes (got %d)", count)
+		}
+
+		if output.Len() != 0 {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Diff", func(t *testing.T) {
+		content := "Hello world!\n"
+		reader := stringsx.NewReader(content)
+		output := new(bytes.Buffer)
+
+		count, err := checkInput(reader, "foo.txt", replace, getDiffReporter(3), output)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if count != 2 {
+			t.Errorf("Unexpected number of changes (got %d)", count)
+		}
+
+		expected := `--- a/foo.txt
++++ b/foo.txt
+@@ -1,1 +1,1 @@
+-Hello world!
++Hey planet!
+`
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Reading error", func(t *testing.T) {
+		reader := iotest.TimeoutReader(stringsx.NewReader("Hello world"))
+		output := new(bytes.Buffer)
+
+		_, err := checkInput(reader, "foo.txt", replace, reportChanges, output)
+		if err == nil {
+			t.Fatal("Expected an error but got none")
+		}
+	})
+}
+
+func TestCheckInputFiles(t *testing.T) {
+	mapping := []wordrow.Rule{{From: "hello", To: "hey"}}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
+	replace := r.Replace
+
+	t.Run("Missing files", func(t *testing.T) {
+		filePaths := []string{"this-file-does-not-exist", "neither-does-this-one"}
+		output := new(bytes.Buffer)
+
+		count, errs := checkInputFiles(filePaths, replace, reportChanges, output)
+		if len(errs) != len(filePaths) {
+			t.Errorf("Unexpected number of errors (got %d)", len(errs))
+		}
+
+		if count != 0 {
+			t.Errorf("Unexpected number of changes (got %d)", count)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+func TestCollector(t *testing.T) {
+	content := []byte("Hello world!\nHello planet!\n")
+	changes := []wordrow.Change{
+		{Start: 0, End: 5, Original: "Hello", Replacement: "Hey"},
+		{Start: 13, End: 18, Original: "Hello", Replacement: "Hey"},
+	}
+
+	t.Run("Positions", func(t *testing.T) {
+		c := new(wordrow.Report)
+		c.Record("foo.txt", content, changes)
+
+		entries := c.Files()
+		if len(entries) != 1 {
+			t.Fatalf("Unexpected number of entries (got %d)", len(entries))
+		}
+
+		expected := [][4]int{{1, 1, 1, 6}, {2, 1, 2, 6}}
+		for i, p := range entries[0].Matches {
+			if p.Line != expected[i][0] || p.Column != expected[i][1] {
+				t.Errorf("Unexpected position of change %d (got %d:%d)", i, p.Line, p.Column)
+			}
+
+			if p.EndLine != expected[i][2] || p.EndColumn != expected[i][3] {
+				t.Errorf("Unexpected end of change %d (got %d:%d)", i, p.EndLine, p.EndColumn)
+			}
+		}
+	})
+	t.Run("Sorted", func(t *testing.T) {
+		c := new(wordrow.Report)
+
+		var wg sync.WaitGroup
+		for _, name := range []string{"c.txt", "a.txt", "b.txt"} {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				c.Record(name, content, changes)
+			}(name)
+		}
+
+		wg.Wait()
+
+		entries := c.Files()
+		for i, expected := range []string{"a.txt", "b.txt", "c.txt"} {
+			if entries[i].Name != expected {
+				t.Errorf("Unexpected entry at %d (got '%s')", i, entries[i].Name)
+			}
+		}
+	})
+}
@@ -5,4 +5,5 @@ const (
 	missingArgumentExitCode = iota + 1
 	runtimeErrorExitCode
 	runtimeWarningExitCode
+	checkFailedExitCode
 )
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// The answers to the question whether to make a replacement.
+const (
+	// Make the replacement.
+	answerAccept = "y"
+
+	// Don't make the replacement.
+	answerReject = "n"
+
+	// Make the replacement and all other replacements for the same mapping.
+	answerAcceptAll = "a"
+
+	// Don't make any replacement in the current input.
+	answerSkip = "s"
+
+	// Don't make any replacement in the current input and stop.
+	answerQuit = "q"
+)
+
+// The question asked for every replacement in an interactive session.
+const question = "Replace? [y]es, [n]o, [a]ll for this mapping, [s]kip file, [q]uit: "
+
+// The ANSI escape codes to start and end highlighting text.
+const (
+	highlightStart = "\x1b[7m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// Check that reviewing replacements, as requested by the `args`, can be combined
+// with the other modes. The user is asked on STDIN, so it cannot be combined
+// with processing STDIN, nor with modes that do not update files as they go.
+//
+// The error will be set if reviewing replacements cannot be combined with the
+// other modes.
+func checkInteractiveArgs(args *cli.Arguments, stdin bool) error {
+	if !args.Interactive {
+		return nil
+	}
+
+	switch {
+	case stdin:
+		return errors.New("The --interactive flag cannot be used for STDIN")
+	case args.Check:
+		return errors.New("The --interactive flag cannot be used with --check")
+	case args.Diff:
+		return errors.New("The --interactive flag cannot be used with --diff")
+	case args.DryRun:
+		return errors.New("The --interactive flag cannot be used with --dry-run")
+	case args.Watch:
+		return errors.New("The --interactive flag cannot be used with --watch")
+	}
+
+	return nil
+}
+
+// The session type represents an interactive session in which the user decides
+// on every replacement. The replacements in an input are only made if the user
+// neither skipped the input nor quit the session.
+type session struct {
+	// The input to read the answers of the user from.
+	input *bufio.Reader
+
+	// The output to write the questions for the user to.
+	output io.Writer
+
+	// The name of the current input.
+	name string
+
+	// The mappings for which the user accepted all replacements.
+	accepted map[wordrow.Rule]bool
+
+	// Flag indicating whether the user skipped the current input.
+	skipped bool
+
+	// Flag indicating whether the user quit the session.
+	quit bool
+}
+
+// Create a new session that asks questions on the `output` and reads answers
+// from the `input`.
+func newSession(input io.Reader, output io.Writer) *session {
+	return &session{
+		input:    bufio.NewReader(input),
+		output:   output,
+		accepted: make(map[wordrow.Rule]bool),
+	}
+}
+
+// Start deciding on the replacements in the input `name`.
+func (s *session) begin(name string) {
+	s.name = name
+	s.skipped = false
+}
+
+// Check whether the replacements in the current input should be discarded.
+func (s *session) aborted() bool {
+	return s.skipped || s.quit
+}
+
+// Show the replacement `c` in `content` to the user. The line(s) containing the
+// replacement are shown with the replaced text highlighted.
+func (s *session) show(content []byte, c wordrow.Change) {
+	lineStart := bytes.LastIndexByte(content[:c.Start], '\n') + 1
+	lineEnd := bytes.IndexByte(content[c.End:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += c.End
+	}
+
+	fmt.Fprintf(s.output, "\n%s\n", formatChange(s.name, content, c))
+	fmt.Fprintf(
+		s.output,
+		"%s%s%s%s%s\n",
+		content[lineStart:c.Start],
+		highlightStart,
+		content[c.Start:c.End],
+		highlightEnd,
+		content[c.End:lineEnd],
+	)
+}
+
+// Ask the user whether to make a replacement until a valid answer is given. If
+// there is no more input the user is considered to have quit.
+func (s *session) ask() string {
+	for {
+		fmt.Fprint(s.output, question)
+
+		line, err := s.input.ReadString('\n')
+		answer := stringsx.ToLower(stringsx.TrimSpace(line))
+		switch answer {
+		case answerAccept, answerReject, answerAcceptAll, answerSkip, answerQuit:
+			return answer
+		}
+
+		if err != nil {
+			fmt.Fprintln(s.output)
+			return answerQuit
+		}
+	}
+}
+
+// Decide whether to make the replacement `c` in `content` by asking the user,
+// see wordrow.Decider.
+func (s *session) decide(content []byte, c wordrow.Change) bool {
+	if s.aborted() {
+		return false
+	}
+
+	if s.accepted[c.Rule] {
+		return true
+	}
+
+	s.show(content, c)
+	switch s.ask() {
+	case answerAccept:
+		return true
+	case answerAcceptAll:
+		s.accepted[c.Rule] = true
+		return true
+	case answerSkip:
+		s.skipped = true
+	case answerQuit:
+		s.quit = true
+	}
+
+	return false
+}
+
+// Update the contents of all files specified by `filePaths` using the Replacer
+// `r`, as configured by the `options`, asking the user of the session `s` to
+// decide on every replacement, see processInputFiles. The files are processed
+// one after the other and no more files are processed once the user quit the
+// session. If the user skipped a file or quit the session that file is left as
+// is.
+func reviewInputFiles(
+	filePaths []string,
+	s *session,
+	r *wordrow.Replacer,
+	options wordrow.FileOptions,
+) (summary, []error) {
+	options.Decide = s.decide
+	options.Confirm = func(_ string) bool {
+		return !s.aborted()
+	}
+
+	var results []wordrow.FileResult
+	for _, filePath := range filePaths {
+		if s.quit {
+			break
+		}
+
+		s.begin(filePath)
+		result := r.ReplaceFile(filePath, options)
+		logResult(&result)
+		results = append(results, result)
+	}
+
+	return summarize(results)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// Create a Change that replaces the text from `start` to `end` in `content` by
+// `replacement`.
+func changeAt(content []byte, start, end int, replacement string) wordrow.Change {
+	original := string(content[start:end])
+	return wordrow.Change{
+		Rule:        wordrow.Rule{From: original, To: replacement},
+		Start:       start,
+		End:         end,
+		Original:    original,
+		Replacement: replacement,
+	}
+}
+
+func TestCheckInteractiveArgs(t *testing.T) {
+	valid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{}, stdin: true},
+		{args: cli.Arguments{Interactive: true}, stdin: false},
+		{args: cli.Arguments{Interactive: true, Report: cli.ReportJSON}, stdin: false},
+	}
+
+	for _, c := range valid {
+		if err := checkInteractiveArgs(&c.args, c.stdin); err != nil {
+			t.Errorf("Unexpected error for %+v (got '%s')", c, err)
+		}
+	}
+
+	invalid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{Interactive: true}, stdin: true},
+		{args: cli.Arguments{Interactive: true, Check: true}, stdin: false},
+		{args: cli.Arguments{Interactive: true, Diff: true}, stdin: false},
+		{args: cli.Arguments{Interactive: true, DryRun: true}, stdin: false},
+		{args: cli.Arguments{Interactive: true, Watch: true}, stdin: false},
+	}
+
+	for _, c := range invalid {
+		if err := checkInteractiveArgs(&c.args, c.stdin); err == nil {
+			t.Errorf("Expected an error for %+v", c)
+		}
+	}
+}
+
+func TestSessionShow(t *testing.T) {
+	content := []byte("Hello world!\nA cat and a dog.\nBye")
+	change := changeAt(content, 15, 18, "dog")
+
+	output := new(bytes.Buffer)
+	s := newSession(stringsx.NewReader(""), output)
+	s.begin("foo.txt")
+	s.show(content, change)
+
+	expected := "\nfoo.txt:2:3: \"cat\" -> \"dog\"\nA " + highlightStart + "cat" + highlightEnd + " and a dog.\n"
+	if output.String() != expected {
+		t.Errorf("Unexpected output (got %q)", output)
+	}
+}
+
+func TestSessionDecide(t *testing.T) {
+	content := []byte("A cat and a cat.")
+	cat := changeAt(content, 2, 5, "dog")
+
+	decide := func(answers string) (*session, bool) {
+		s := newSession(stringsx.NewReader(answers), ioutil.Discard)
+		s.begin("foo.txt")
+		return s, s.decide(content, cat)
+	}
+
+	t.Run("Accept", func(t *testing.T) {
+		if _, ok := decide("y\n"); !ok {
+			t.Error("Expected the replacement to be accepted")
+		}
+	})
+	t.Run("Reject", func(t *testing.T) {
+		if _, ok := decide("n\n"); ok {
+			t.Error("Expected the replacement to be rejected")
+		}
+	})
+	t.Run("Accept all", func(t *testing.T) {
+		s, ok := decide("a\n")
+		if !ok {
+			t.Error("Expected the replacement to be accepted")
+		}
+
+		if !s.decide(content, cat) {
+			t.Error("Expected the replacement to be accepted without asking")
+		}
+	})
+	t.Run("Skip", func(t *testing.T) {
+		s, ok := decide("s\ny\n")
+		if ok {
+			t.Error("Expected the replacement to be rejected")
+		}
+
+		if s.decide(content, cat) {
+			t.Error("Expected the replacement to be rejected without asking")
+		}
+
+		s.begin("bar.txt")
+		if !s.decide(content, cat) {
+			t.Error("Expected the replacement in the next input to be accepted")
+		}
+	})
+	t.Run("Quit", func(t *testing.T) {
+		s, ok := decide("q\ny\n")
+		if ok {
+			t.Error("Expected the replacement to be rejected")
+		}
+
+		s.begin("bar.txt")
+		if s.decide(content, cat) {
+			t.Error("Expected the replacement to be rejected without asking")
+		}
+	})
+	t.Run("Invalid answer", func(t *testing.T) {
+		if _, ok := decide("maybe\n\nY\n"); !ok {
+			t.Error("Expected the replacement to be accepted")
+		}
+	})
+	t.Run("No answer", func(t *testing.T) {
+		s, ok := decide("")
+		if ok {
+			t.Error("Expected the replacement to be rejected")
+		}
+
+		if !s.quit {
+			t.Error("Expected the session to be quit")
+		}
+	})
+}
+
+func TestReviewInputFiles(t *testing.T) {
+	mapping := []wordrow.Rule{{From: "cat", To: "dog"}}
+
+	review := func(answers string, contents ...string) ([]string, summary) {
+		t.Helper()
+
+		var filePaths []string
+		for _, content := range contents {
+			path, cleanup := createTempFile(t, "foo.txt", content)
+			defer cleanup()
+
+			filePaths = append(filePaths, path)
+		}
+
+		s := newSession(stringsx.NewReader(answers), ioutil.Discard)
+		r, _ := getReplacer(mapping, &cli.Arguments{})
+		sum, errs := reviewInputFiles(filePaths, s, r, wordrow.FileOptions{})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		var results []string
+		for _, path := range filePaths {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Could not read the file (%s)", err)
+			}
+
+			results = append(results, string(content))
+		}
+
+		return results, sum
+	}
+
+	t.Run("Accepted replacements only", func(t *testing.T) {
+		results, sum := review("y\nn\ny\n", "cat cat cat")
+		if results[0] != "dog cat dog" {
+			t.Errorf("Unexpected content (got '%s')", results[0])
+		}
+
+		if sum.changed != 1 {
+			t.Errorf("Unexpected summary (got %+v)", sum)
+		}
+	})
+	t.Run("Skip file", func(t *testing.T) {
+		results, sum := review("y\ns\ny\n", "cat cat", "cat")
+		if results[0] != "cat cat" || results[1] != "dog" {
+			t.Errorf("Unexpected contents (got %q)", results)
+		}
+
+		if sum.changed != 1 || sum.unchanged != 1 {
+			t.Errorf("Unexpected summary (got %+v)", sum)
+		}
+	})
+	t.Run("Quit", func(t *testing.T) {
+		results, sum := review("y\nq\n", "cat cat", "cat")
+		if results[0] != "cat cat" || results[1] != "cat" {
+			t.Errorf("Unexpected contents (got %q)", results)
+		}
+
+		if sum.changed != 0 || sum.unchanged != 1 {
+			t.Errorf("Unexpected summary (got %+v)", sum)
+		}
+	})
+}
+
+func TestReviewInputFilesOriginalPositions(t *testing.T) {
+	mapping := []wordrow.Rule{
+		{From: "cat", To: "doggo"},
+		{From: "mouse", To: "rat"},
+	}
+
+	path, cleanup := createTempFile(t, "foo.txt", "cat and mouse")
+	defer cleanup()
+
+	output := new(bytes.Buffer)
+	s := newSession(stringsx.NewReader("y\ny\n"), output)
+	r, _ := getReplacer(mapping, &cli.Arguments{})
+	if _, errs := reviewInputFiles([]string{path}, s, r, wordrow.FileOptions{}); len(errs) != 0 {
+		t.Fatalf("Unexpected errors (got %v)", errs)
+	}
+
+	expected := ":1:9: \"mouse\" -> \"rat\"\ncat and " + highlightStart + "mouse" + highlightEnd + "\n"
+	if !stringsx.Contains(output.String(), expected) {
+		t.Errorf("Unexpected output (got %q)", output)
+	}
+}
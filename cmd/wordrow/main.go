@@ -2,48 +2,143 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"os"
 
 	"github.com/ericcornelissen/wordrow/internal/cli"
-	"github.com/ericcornelissen/wordrow/internal/fs"
 	"github.com/ericcornelissen/wordrow/internal/logger"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
-func run(args *cli.Arguments) (errors, warnings []error) {
+func run(args *cli.Arguments) (errors, warnings []error, changed bool) {
+	report := new(wordrow.Report)
 	if hasStdin() {
 		logger.SetLogLevel(logger.FATAL)
-		errors, warnings = runOnStdin(args)
+		errors, warnings, changed = runOnStdin(args, report)
 	} else {
 		setLogLevel(args)
-		errors, warnings = runOnFiles(args)
+		errors, warnings, changed = runOnFiles(args, report)
 	}
 
-	return errors, warnings
+	err := writeReport(os.Stdout, args.Report, report, errors, warnings)
+	if err != nil {
+		errors = append(errors, err)
+	}
+
+	return errors, warnings, changed
 }
 
-func runOnFiles(args *cli.Arguments) (errors, warnings []error) {
+func runOnFiles(
+	args *cli.Arguments,
+	report *wordrow.Report,
+) (errors, warnings []error, changed bool) {
+	if err := checkArgs(args, false); err != nil {
+		logger.Error(err)
+		return []error{err}, nil, false
+	}
+
 	mapping, errs := getMapping(args)
 	if check(&warnings, errs) && args.Strict {
-		return nil, warnings
+		return nil, warnings, false
+	}
+
+	r, errs := getReplacer(mapping, args)
+	if check(&warnings, errs) && args.Strict {
+		return nil, warnings, false
 	}
 
-	filePaths, errs := fs.ResolveGlobs(args.InputFiles...)
+	filePaths, errs := wordrow.ResolveFiles(
+		getWalkOptions(args),
+		args.InputFiles...,
+	)
 	if check(&warnings, errs) && args.Strict {
-		return nil, errs
+		return nil, warnings, false
+	}
+
+	if args.Check || args.Diff {
+		count, errs := checkInputFiles(
+			filePaths,
+			r.Replace,
+			getReporter(args, report),
+			os.Stdout,
+		)
+		check(&errors, errs)
+
+		if !args.Diff {
+			logger.Infof("Found %d replacement(s) in %d file(s)", count, len(filePaths))
+		}
+
+		return errors, warnings, args.Check && count > 0
+	}
+
+	if args.Watch {
+		watchFiles(args, r, report, watchInterval, interrupted())
+		return errors, warnings, false
 	}
 
 	if !args.DryRun {
-		errs = processInputFiles(filePaths, mapping)
+		var s summary
+		if args.Interactive {
+			session := newSession(os.Stdin, os.Stdout)
+			s, errs = reviewInputFiles(
+				filePaths,
+				session,
+				r,
+				getFileOptions(args, report),
+			)
+		} else {
+			s, errs = processInputFiles(
+				context.Background(),
+				filePaths,
+				r,
+				getFileOptions(args, report),
+			)
+		}
+
 		check(&errors, errs)
+
+		logger.Infof(
+			"%d file(s) changed, %d file(s) unchanged",
+			s.changed,
+			s.unchanged,
+		)
 	}
 
-	return errors, warnings
+	return errors, warnings, false
 }
 
-func runOnStdin(args *cli.Arguments) (errors, warnings []error) {
+func runOnStdin(
+	args *cli.Arguments,
+	report *wordrow.Report,
+) (errors, warnings []error, changed bool) {
+	if err := checkArgs(args, true); err != nil {
+		logger.Error(err)
+		return []error{err}, nil, false
+	}
+
 	mapping, errs := getMapping(args)
 	if check(&warnings, errs) && args.Strict {
-		return nil, warnings
+		return nil, warnings, false
+	}
+
+	r, errs := getReplacer(mapping, args)
+	if check(&warnings, errs) && args.Strict {
+		return nil, warnings, false
+	}
+
+	if args.Check || args.Diff {
+		count, err := checkInput(
+			os.Stdin,
+			args.StdinName,
+			r.Replace,
+			getReporter(args, report),
+			os.Stdout,
+		)
+		if err != nil {
+			errors = append(errors, err)
+		}
+
+		return errors, warnings, args.Check && count > 0
 	}
 
 	readWriter := bufio.NewReadWriter(
@@ -51,12 +146,33 @@ func runOnStdin(args *cli.Arguments) (errors, warnings []error) {
 		bufio.NewWriter(os.Stdout),
 	)
 
-	err := processStdin(readWriter, mapping)
+	err := processStdin(
+		readWriter,
+		r,
+		wordrow.DefaultStreamThreshold,
+	)
 	if err != nil {
 		errors = append(errors, err)
 	}
 
-	return errors, warnings
+	return errors, warnings, false
+}
+
+// Check that the options and flags in the `args` can be combined, for STDIN if
+// `stdin` is set, see checkReportArgs, checkInteractiveArgs, and
+// checkWatchArgs.
+//
+// The error will be set if they cannot be combined.
+func checkArgs(args *cli.Arguments, stdin bool) error {
+	if err := checkReportArgs(args, stdin); err != nil {
+		return err
+	}
+
+	if err := checkInteractiveArgs(args, stdin); err != nil {
+		return err
+	}
+
+	return checkWatchArgs(args, stdin)
 }
 
 func check(errors *[]error, newErrors []error) bool {
@@ -65,14 +181,16 @@ func check(errors *[]error, newErrors []error) bool {
 }
 
 func setLogLevel(args *cli.Arguments) {
-	if args.Silent {
+	if args.Report != "" {
+		logger.SetLogLevel(logger.FATAL)
+	} else if args.Silent {
 		logger.SetLogLevel(logger.ERROR)
 	} else if args.Verbose {
 		logger.SetLogLevel(logger.DEBUG)
 	}
 }
 
-func exit(errors, warnings []error, strict bool) {
+func exit(errors, warnings []error, changed, strict bool) {
 	if len(errors) > 0 {
 		os.Exit(runtimeErrorExitCode)
 	}
@@ -81,6 +199,10 @@ func exit(errors, warnings []error, strict bool) {
 		os.Exit(runtimeWarningExitCode)
 	}
 
+	if changed {
+		os.Exit(checkFailedExitCode)
+	}
+
 	os.Exit(0)
 }
 
@@ -94,6 +216,6 @@ func main() {
 		os.Exit(missingArgumentExitCode)
 	}
 
-	errors, warnings := run(&args)
-	exit(errors, warnings, args.Strict)
+	errors, warnings, changed := run(&args)
+	exit(errors, warnings, changed, args.Strict)
 }
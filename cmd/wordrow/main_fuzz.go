@@ -7,7 +7,7 @@ import (
 
 	"github.com/ericcornelissen/stringsx"
 	"github.com/ericcornelissen/wordrow/internal/cli"
-	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
 const (
@@ -26,20 +26,19 @@ func dataToInputs(data []byte) ([]string, error) {
 	return inputs, nil
 }
 
-func _processMapFile(s, format string, mapping map[string]string) {
+func _processMapFile(s, format string, mapping *[]wordrow.Rule) {
 	s = stringsx.ReplaceAll(s, ";", "\n")
 	mapfileReader := stringsx.NewReader(s)
-	newMapping, err := processMapFile(mapfileReader, format)
+	newMapping, err := wordrow.ParseRules(mapfileReader, format)
 	if err == nil {
-		common.MergeMaps(mapping, newMapping)
+		*mapping = wordrow.MergeRules(*mapping, newMapping)
 	}
 }
 
-func _doReplace(s string, mapping map[string]string) string {
+func _doReplace(s string, replace replacer) string {
 	s = stringsx.ReplaceAll(s, ";", "\n")
-	inputfileReader := stringsx.NewReader(s)
-	output, _ := doReplace(inputfileReader, mapping)
-	return output
+	updatedContent, _ := replace([]byte(s))
+	return string(updatedContent)
 }
 
 func Fuzz(data []byte) int {
@@ -51,16 +50,17 @@ func Fuzz(data []byte) int {
 	rawArgs := stringsx.Split(inputs[0], ";")
 	_, args := cli.ParseArgs(rawArgs)
 
-	mapping := make(map[string]string)
-	forEach(args.Mappings, processInlineMappingWith(mapping))
-	_processMapFile(inputs[1], csv, mapping)
-	_processMapFile(inputs[2], markdown, mapping)
+	var mapping []wordrow.Rule
+	forEach(args.Mappings, processInlineMappingWith(&mapping))
+	_processMapFile(inputs[1], csv, &mapping)
+	_processMapFile(inputs[2], markdown, &mapping)
 
 	if args.Invert {
-		mapping = invert(mapping)
+		mapping = wordrow.InvertRules(mapping)
 	}
 
-	output := _doReplace(inputs[3], mapping)
+	r, _ := getReplacer(mapping, &args)
+	output := _doReplace(inputs[3], r.Replace)
 	if output != inputs[3] {
 		return 1
 	}
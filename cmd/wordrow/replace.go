@@ -2,104 +2,112 @@ package main
 
 import (
 	"bufio"
-	"io/ioutil"
+	"context"
 
+	"github.com/ericcornelissen/wordrow/internal/cli"
 	"github.com/ericcornelissen/wordrow/internal/errors"
-	"github.com/ericcornelissen/wordrow/internal/fs"
 	"github.com/ericcornelissen/wordrow/internal/logger"
-	"github.com/ericcornelissen/wordrow/internal/replace"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
-// Reads the contents from the `reader` and updates the content based on the
-// `mapping`.
-func doReplace(
-	reader fs.Reader,
-	mapping map[string]string,
-) (updatedContent []byte, er error) {
-	data, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return updatedContent, err
-	}
+// A replacer is a function that replaces words in `s` based on a mapping. It
+// returns the updated `s` as well as the changes made to `s`.
+type replacer func(s []byte) ([]byte, []wordrow.Change)
 
-	return replace.All(data, mapping), nil
-}
+// The summary type represents the outcome of processing a number of files.
+type summary struct {
+	// The number of files whose content was changed.
+	changed int
 
-// Writes the `updatedContents` to the `writer`.
-func doWriteBack(writer fs.Writer, data []byte) error {
-	_, err := writer.Write(data)
-	return err
+	// The number of files whose content was left as is.
+	unchanged int
 }
 
-// Process the `input` provided by the ReadWriter, changing that based on the
-// `mapping`, and write the updated content back to the ReadWriter.
-func processStdin(rw *bufio.ReadWriter, mapping map[string]string) error {
-	input := bufio.NewScanner(rw.Reader)
-	output := rw.Writer
-
-	for input.Scan() {
-		line := input.Bytes()
-		fixedLine := replace.All(line, mapping)
-		output.Write(fixedLine)
-		output.WriteRune('\n')
+// Get the Replacer for the `rules` as configured by the `args`. The `rules` are
+// compiled once so the Replacer can be reused for every input. A warning is
+// logged for every rule that is invalid and therefore left out.
+//
+// The function returns an error for every rule that is left out.
+func getReplacer(
+	rules []wordrow.Rule,
+	args *cli.Arguments,
+) (*wordrow.Replacer, []error) {
+	r, errs := wordrow.New(rules, wordrow.Options{
+		Simultaneous: args.Simultaneous,
+	})
+	for _, err := range errs {
+		logger.Warning(err)
 	}
 
-	return output.Flush()
+	return r, errs
 }
 
-// Process `file` by reading its content, changing that based on the `mapping`,
-// and writing the updated content back to `file`. If a reading or writing error
-// occurs this function returns an error.
-func processFile(file fs.ReadWriter, mapping map[string]string) error {
-	logger.Debugf("Reading '%s' and replacing words", file)
-	updatedContent, err := doReplace(file, mapping)
-	if err != nil {
-		return errors.Newf("Could not read from file '%s'", file)
+// Get the options to update input files with as configured by the `args`. If
+// a report is requested the changes are recorded in the `report`.
+func getFileOptions(
+	args *cli.Arguments,
+	report *wordrow.Report,
+) wordrow.FileOptions {
+	options := wordrow.FileOptions{
+		Fsync:    args.Fsync,
+		Jobs:     args.Jobs,
+		FailFast: args.Strict,
+	}
+
+	if args.Report != "" {
+		options.Report = report
 	}
 
-	logger.Debugf("Writing updated contents to '%s'", file)
-	err = doWriteBack(file, updatedContent)
+	return options
+}
+
+// Process the `input` provided by the ReadWriter as a whole, changing that
+// using the Replacer `r`, and write the updated content back to the
+// ReadWriter. Like the content of a file, the input is processed as a single
+// document, so mappings can match across lines and the updated content ends
+// with a newline if, and only if, the input does. If the input is larger than
+// the `threshold` it is streamed, see wordrow.Replacer.ReplaceReader.
+func processStdin(
+	rw *bufio.ReadWriter,
+	r *wordrow.Replacer,
+	threshold int64,
+) error {
+	_, err := r.ReplaceReader(rw.Writer, rw.Reader, threshold)
 	if err != nil {
-		return errors.Newf("Could not write to file '%s'", file)
+		return errors.New("Could not process stdin")
 	}
 
-	return nil
+	return rw.Writer.Flush()
 }
 
-// Opens the file provided by the handler and process it using the `mapping`. If
-// opening the file fails or a reading or writing error occurs the error is
-// outputted to the channel `ch`.
-func openAndProcessFileWith(
-	ch chan error,
-	mapping map[string]string,
-) func(value string) {
-	return func(filePath string) {
-		logger.Debugf("Opening '%s'", filePath)
-		handle, err := fs.OpenFile(filePath, fs.OReadWrite)
-		if err != nil {
-			ch <- err
-		}
-
-		defer handle.Close()
-
-		logger.Debugf("Processing '%s'", filePath)
-		ch <- processFile(handle, mapping)
+// Log the outcome of updating a file, described by the result `r`.
+func logResult(r *wordrow.FileResult) {
+	if r.Err != nil {
+		return
+	}
+
+	if r.Changed {
+		logger.Infof("Changed '%s'", r.Path)
+	} else {
+		logger.Infof("Unchanged '%s'", r.Path)
 	}
 }
 
-// Update the contents of all files specified by `filePaths` based on the
-// `mapping`. Any error that occurs is returned after all files have been
-// processed.
+// Update the contents of all files specified by `filePaths` using the Replacer
+// `r`, as configured by the `options`, see wordrow.Replacer.ReplaceFiles. It
+// returns a summary of the number of files that were and were not changed. Any
+// error that occurs is returned after all files have been processed, in the
+// order of `filePaths`.
 func processInputFiles(
+	ctx context.Context,
 	filePaths []string,
-	mapping map[string]string,
-) (errs []error) {
-	ch := make(chan error, len(filePaths))
-	defer close(ch)
-
-	openAndProcessFile := openAndProcessFileWith(ch, mapping)
-	for _, filePath := range filePaths {
-		go openAndProcessFile(filePath)
+	r *wordrow.Replacer,
+	options wordrow.FileOptions,
+) (summary, []error) {
+	results := r.ReplaceFiles(ctx, filePaths, options)
+	for i := range results {
+		logResult(&results[i])
 	}
 
-	return drain(ch, len(filePaths))
+	return summarize(results)
 }
@@ -3,49 +3,211 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"testing/iotest"
 
 	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
+// Create a temporary directory with a file named `name` containing `content`.
+// It returns the path of the file and a function to remove the directory.
+func createTempFile(t *testing.T, name, content string) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	cleanup := func() { os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		cleanup()
+		t.Fatalf("Could not create a temporary file (%s)", err)
+	}
+
+	return path, cleanup
+}
+
+func TestGetReplacer(t *testing.T) {
+	mapping := []wordrow.Rule{
+		{From: "dog", To: "cat"},
+		{From: "cat", To: "dog"},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		r, _ := getReplacer(mapping, &cli.Arguments{})
+
+		fixed, _ := r.Replace([]byte("dog cat"))
+		if string(fixed) != "dog dog" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+	})
+	t.Run("Simultaneous", func(t *testing.T) {
+		r, _ := getReplacer(mapping, &cli.Arguments{Simultaneous: true})
+
+		fixed, _ := r.Replace([]byte("dog cat"))
+		if string(fixed) != "cat dog" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+	})
+	t.Run("Invalid rules", func(t *testing.T) {
+		invalid := append([]wordrow.Rule{{From: "x", To: "-"}}, mapping...)
+		r, errs := getReplacer(invalid, &cli.Arguments{})
+		if len(errs) != 1 {
+			t.Errorf("Expected one error for the invalid rule (got %v)", errs)
+		}
+
+		fixed, _ := r.Replace([]byte("dog cat x"))
+		if string(fixed) != "dog dog x" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+	})
+}
+
+func TestGetReplacers(t *testing.T) {
+	mapping := []wordrow.Rule{
+		{From: "dog", To: "cat"},
+		{From: "cat", To: "dog"},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		r, _ := getReplacer(mapping, &cli.Arguments{})
+
+		var bb bytes.Buffer
+		if _, err := r.Stream(&bb, stringsx.NewReader("dog cat")); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if bb.String() != "dog dog" {
+			t.Errorf("Unexpected result (got '%s')", bb.String())
+		}
+	})
+	t.Run("Simultaneous", func(t *testing.T) {
+		r, _ := getReplacer(mapping, &cli.Arguments{Simultaneous: true})
+
+		var bb bytes.Buffer
+		if _, err := r.Stream(&bb, stringsx.NewReader("dog cat")); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if bb.String() != "cat dog" {
+			t.Errorf("Unexpected result (got '%s')", bb.String())
+		}
+	})
+	t.Run("Report", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "dog.txt", "dog cat")
+		defer cleanup()
+
+		r, _ := getReplacer(mapping, &cli.Arguments{})
+		report := new(wordrow.Report)
+		options := getFileOptions(&cli.Arguments{Report: cli.ReportJSON}, report)
+		options.StreamThreshold = 1
+
+		if result := r.ReplaceFile(path, options); result.Err != nil {
+			t.Fatalf("Unexpected error (%s)", result.Err)
+		}
+
+		if len(report.Files()) != 1 {
+			t.Error("Expected no streaming when reporting")
+		}
+	})
+}
+
+func TestGetFileOptions(t *testing.T) {
+	report := new(wordrow.Report)
+
+	t.Run("Default", func(t *testing.T) {
+		options := getFileOptions(&cli.Arguments{}, report)
+		if options.Fsync || options.FailFast || options.Jobs != 0 || options.Report != nil {
+			t.Errorf("Unexpected options (got %+v)", options)
+		}
+	})
+	t.Run("Configured", func(t *testing.T) {
+		args := &cli.Arguments{Fsync: true, Jobs: 3, Strict: true}
+
+		options := getFileOptions(args, report)
+		if !options.Fsync || !options.FailFast || options.Jobs != 3 {
+			t.Errorf("Unexpected options (got %+v)", options)
+		}
+	})
+	t.Run("Report", func(t *testing.T) {
+		options := getFileOptions(&cli.Arguments{Report: cli.ReportJSON}, report)
+		if options.Report != report {
+			t.Errorf("Unexpected options (got %+v)", options)
+		}
+	})
+}
+
+func TestGetOpenFlag(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		options := getFileOptions(&cli.Arguments{}, nil)
+		if options.Fsync {
+			t.Errorf("Unexpected options (got %+v)", options)
+		}
+	})
+	t.Run("Fsync", func(t *testing.T) {
+		options := getFileOptions(&cli.Arguments{Fsync: true}, nil)
+		if !options.Fsync {
+			t.Errorf("Unexpected options (got %+v)", options)
+		}
+	})
+}
+
 func TestDoReplace(t *testing.T) {
-	mapping := make(map[string]string, 1)
-	mapping["foo"] = "bar"
+	mapping := []wordrow.Rule{{From: "foo", To: "bar"}}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
 
 	t.Run("Replace something", func(t *testing.T) {
 		content := "Foo Bar"
 		handle := stringsx.NewReader(content)
 
-		fixed, err := doReplace(handle, mapping)
+		var updatedContent bytes.Buffer
+		changes, err := r.ReplaceReader(&updatedContent, handle, -1)
 		if err != nil {
 			t.Fatalf("Unexpected error for reader (%s)", err)
 		}
 
-		if bytes.Equal(fixed, []byte(content)) {
+		if updatedContent.String() == content {
 			t.Error("Content should have been changed but wasn't")
 		}
+
+		if len(changes) != 1 {
+			t.Errorf("Unexpected number of changes (got %d)", len(changes))
+		}
 	})
 	t.Run("Replace nothing", func(t *testing.T) {
 		content := "Bar"
 		handle := stringsx.NewReader(content)
 
-		fixed, err := doReplace(handle, mapping)
+		var updatedContent bytes.Buffer
+		changes, err := r.ReplaceReader(&updatedContent, handle, -1)
 		if err != nil {
 			t.Fatalf("Unexpected error for reader (%s)", err)
 		}
 
-		if !bytes.Equal(fixed, []byte(content)) {
-			t.Errorf("Content should not have been changed but was (got '%s')", fixed)
+		if updatedContent.String() != content {
+			t.Errorf("Content should not have been changed but was (got '%s')", updatedContent.String())
+		}
+
+		if len(changes) != 0 {
+			t.Error("Expected the content to be reported as unchanged")
 		}
 	})
 	t.Run("Reading error", func(t *testing.T) {
 		content := "Hello world"
 		handle := iotest.TimeoutReader(stringsx.NewReader(content))
 
-		_, err := doReplace(handle, mapping)
+		var updatedContent bytes.Buffer
+		_, err := r.ReplaceReader(&updatedContent, handle, -1)
 		if err == nil {
 			t.Error("Expected an error but didn't get one")
 		}
@@ -53,24 +215,26 @@ func TestDoReplace(t *testing.T) {
 	t.Run("Empty reader", func(t *testing.T) {
 		handle := stringsx.NewReader("")
 
-		fixed, err := doReplace(handle, mapping)
+		var updatedContent bytes.Buffer
+		_, err := r.ReplaceReader(&updatedContent, handle, -1)
 		if err != nil {
 			t.Fatalf("Unexpected error for reader (%s)", err)
 		}
 
-		if len(fixed) != 0 {
-			t.Errorf("Updated content should have been empty (got '%s')", fixed)
+		if updatedContent.Len() != 0 {
+			t.Errorf("Updated content should have been empty (got '%s')", updatedContent.String())
 		}
 	})
 }
 
 func TestDoWriteBack(t *testing.T) {
 	content := []byte("Hello world!")
+	r, _ := getReplacer(nil, &cli.Arguments{})
 
 	t.Run("Write something", func(t *testing.T) {
 		handle := new(bytes.Buffer)
 
-		err := doWriteBack(handle, content)
+		_, err := r.ReplaceReader(handle, bytes.NewReader(content), -1)
 		if err != nil {
 			t.Fatalf("Unexpected error (%s)", err)
 		}
@@ -87,7 +251,7 @@ func TestDoWriteBack(t *testing.T) {
 
 		handle := iotest.TruncateWriter(os.Stdin, 1)
 
-		err := doWriteBack(handle, content)
+		_, err := r.ReplaceReader(handle, bytes.NewReader(content), -1)
 		if err == nil {
 			t.Fatal("Expected an error but got none")
 		}
@@ -98,13 +262,15 @@ func TestProcessBuffer(t *testing.T) {
 	from0, to0 := "hello", "hey"
 	from1, to1 := "world", "planet"
 
-	mapping := make(map[string]string, 2)
-	mapping[from0] = to0
-	mapping[from1] = to1
+	mapping := []wordrow.Rule{
+		{From: from0, To: to0},
+		{From: from1, To: to1},
+	}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
 
 	t.Run("Replace something", func(t *testing.T) {
 		content := fmt.Sprintf("%s %s", from0, from1)
-		expectedWritten := fmt.Sprintf("%s %s\n", to0, to1)
+		expectedWritten := fmt.Sprintf("%s %s", to0, to1)
 
 		reader := stringsx.NewReader(content)
 		writer := new(bytes.Buffer)
@@ -113,7 +279,7 @@ func TestProcessBuffer(t *testing.T) {
 			bufio.NewWriter(writer),
 		)
 
-		err := processStdin(readWriter, mapping)
+		err := processStdin(readWriter, r, -1)
 		if err != nil {
 			t.Fatalf("Unexpected error (%s)", err)
 		}
@@ -136,7 +302,7 @@ func TestProcessBuffer(t *testing.T) {
 			bufio.NewWriter(writer),
 		)
 
-		err := processStdin(readWriter, mapping)
+		err := processStdin(readWriter, r, -1)
 		if err != nil {
 			t.Fatalf("Unexpected error (%s)", err)
 		}
@@ -146,8 +312,85 @@ func TestProcessBuffer(t *testing.T) {
 			t.Errorf("Unexpected value written (got '%s')", written)
 		}
 	})
+	t.Run("Whole document", func(t *testing.T) {
+		mapping := []wordrow.Rule{{From: "hello world", To: "hey planet"}}
+		r, _ := getReplacer(mapping, &cli.Arguments{})
+
+		cases := map[string]string{
+			"hello\nworld":      "hey\nplanet",
+			"hello world\n":     "hey planet\n",
+			"hello world\n\n":   "hey planet\n\n",
+			"hello world\r\n":   "hey planet\r\n",
+			"Hello\n  World!\n": "Hey\n  Planet!\n",
+			"":                  "",
+		}
+
+		for content, expected := range cases {
+			writer := new(bytes.Buffer)
+			readWriter := bufio.NewReadWriter(
+				bufio.NewReader(stringsx.NewReader(content)),
+				bufio.NewWriter(writer),
+			)
+
+			if err := processStdin(readWriter, r, -1); err != nil {
+				t.Fatalf("Unexpected error (%s)", err)
+			}
+
+			if writer.String() != expected {
+				t.Errorf("Unexpected value written for %q (got %q)", content, writer)
+			}
+		}
+	})
+	t.Run("Long line", func(t *testing.T) {
+		padding := stringsx.Repeat("a", 128*1024)
+		content := fmt.Sprintf("%s %s %s", padding, from0, padding)
+		expectedWritten := fmt.Sprintf("%s %s %s", padding, to0, padding)
+
+		writer := new(bytes.Buffer)
+		readWriter := bufio.NewReadWriter(
+			bufio.NewReader(stringsx.NewReader(content)),
+			bufio.NewWriter(writer),
+		)
+
+		if err := processStdin(readWriter, r, -1); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if writer.String() != expectedWritten {
+			t.Error("Unexpected value written for a long line")
+		}
+	})
+	t.Run("Streaming", func(t *testing.T) {
+		content := fmt.Sprintf("%s\n%s\n", from0, from1)
+		expectedWritten := fmt.Sprintf("%s\n%s\n", to0, to1)
+
+		writer := new(bytes.Buffer)
+		readWriter := bufio.NewReadWriter(
+			bufio.NewReader(stringsx.NewReader(content)),
+			bufio.NewWriter(writer),
+		)
+
+		if err := processStdin(readWriter, r, 4); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if writer.String() != expectedWritten {
+			t.Errorf("Unexpected value written (got '%s')", writer)
+		}
+	})
+	t.Run("Reading error", func(t *testing.T) {
+		writer := new(bytes.Buffer)
+		readWriter := bufio.NewReadWriter(
+			bufio.NewReader(iotest.TimeoutReader(stringsx.NewReader("hello"))),
+			bufio.NewWriter(writer),
+		)
+
+		if err := processStdin(readWriter, r, -1); err == nil {
+			t.Fatal("Expected an error but got none")
+		}
+	})
 	t.Run("Writing error", func(t *testing.T) {
-		content := "foobar"
+		content := fmt.Sprintf("%s %s", from0, from1)
 		if len(content) < 2 {
 			t.Fatal("Content must be at least 2 bytes to ensure the writer errors")
 		}
@@ -159,7 +402,7 @@ func TestProcessBuffer(t *testing.T) {
 			bufio.NewWriterSize(writer, 1),
 		)
 
-		err := processStdin(readWriter, mapping)
+		err := processStdin(readWriter, r, -1)
 		if err == nil {
 			t.Fatal("Expected an error but got none")
 		}
@@ -170,27 +413,29 @@ func TestProcessFile(t *testing.T) {
 	from0, to0 := "hello", "hey"
 	from1, to1 := "world", "planet"
 
-	mapping := make(map[string]string, 2)
-	mapping[from0] = to0
-	mapping[from1] = to1
+	mapping := []wordrow.Rule{
+		{From: from0, To: to0},
+		{From: from1, To: to1},
+	}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
 
 	t.Run("Replace something", func(t *testing.T) {
 		content := fmt.Sprintf("%s %s", from0, from1)
 		expectedWritten := fmt.Sprintf("%s %s", to0, to1)
 
-		reader := stringsx.NewReader(content)
-		writer := new(bytes.Buffer)
-		bufferedReader := bufio.NewReader(reader)
-		bufferedWriter := bufio.NewWriter(writer)
-		handle := bufio.NewReadWriter(bufferedReader, bufferedWriter)
+		path, cleanup := createTempFile(t, "hello.txt", content)
+		defer cleanup()
 
-		err := processFile(handle, mapping)
-		if err != nil {
-			t.Fatalf("Unexpected error (%s)", err)
+		result := r.ReplaceFile(path, wordrow.FileOptions{})
+		if result.Err != nil {
+			t.Fatalf("Unexpected error (%s)", result.Err)
 		}
 
-		bufferedWriter.Flush()
-		written := writer.Bytes()
+		if !result.Changed {
+			t.Error("Expected the file to be reported as changed")
+		}
+
+		written, _ := ioutil.ReadFile(path)
 		if string(written) != expectedWritten {
 			t.Errorf("Unexpected value written (got '%s')", written)
 		}
@@ -201,58 +446,126 @@ func TestProcessFile(t *testing.T) {
 			t.Fatal("Content cannot contain a string that may be replaced")
 		}
 
-		reader := stringsx.NewReader(content)
-		writer := new(bytes.Buffer)
-		bufferedReader := bufio.NewReader(reader)
-		bufferedWriter := bufio.NewWriter(writer)
-		handle := bufio.NewReadWriter(bufferedReader, bufferedWriter)
+		path, cleanup := createTempFile(t, "foo.txt", content)
+		defer cleanup()
 
-		err := processFile(handle, mapping)
-		if err != nil {
-			t.Fatalf("Unexpected error (%s)", err)
+		result := r.ReplaceFile(path, wordrow.FileOptions{})
+		if result.Err != nil {
+			t.Fatalf("Unexpected error (%s)", result.Err)
 		}
 
-		bufferedWriter.Flush()
-		written := writer.Bytes()
+		if result.Changed {
+			t.Error("Expected the file to be reported as unchanged")
+		}
+
+		written, _ := ioutil.ReadFile(path)
 		if string(written) != content {
 			t.Errorf("Unexpected value written (got '%s')", written)
 		}
 	})
 	t.Run("Reading error", func(t *testing.T) {
-		content := "foobar"
+		path, cleanup := createTempFile(t, "foo.txt", "foobar")
+		defer cleanup()
 
-		reader := iotest.TimeoutReader(stringsx.NewReader(content))
-		writer := new(bytes.Buffer)
-		bufferedReader := bufio.NewReader(reader)
-		bufferedWriter := bufio.NewWriter(writer)
-		handle := bufio.NewReadWriter(bufferedReader, bufferedWriter)
-
-		err := processFile(handle, mapping)
-		if err == nil {
+		result := r.ReplaceFile(filepath.Dir(path), wordrow.FileOptions{})
+		if result.Err == nil {
 			t.Fatal("Expected an error but got none")
 		}
 
-		bufferedWriter.Flush()
-		written := writer.Bytes()
-		if string(written) != "" {
+		written, _ := ioutil.ReadFile(path)
+		if string(written) != "foobar" {
 			t.Errorf("Unexpected value written (got '%s')", written)
 		}
 	})
-	t.Run("Writing error", func(t *testing.T) {
-		content := "foobar"
-		if len(content) < 2 {
-			t.Fatal("Content must be at least 2 bytes to ensure the writer errors")
+}
+
+func TestProcessInputFiles(t *testing.T) {
+	mapping := []wordrow.Rule{{From: "hello", To: "hey"}}
+	r, _ := getReplacer(mapping, &cli.Arguments{})
+	options := wordrow.FileOptions{Jobs: 2}
+
+	t.Run("Changed and unchanged files", func(t *testing.T) {
+		changedPath, cleanupChanged := createTempFile(t, "hello.txt", "Hello world!")
+		defer cleanupChanged()
+
+		unchangedPath, cleanupUnchanged := createTempFile(t, "foo.txt", "foobar")
+		defer cleanupUnchanged()
+
+		unchangedInfo, err := os.Stat(unchangedPath)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
 		}
 
-		reader := stringsx.NewReader(content)
-		writer := iotest.TruncateWriter(os.Stdin, 1)
-		bufferedReader := bufio.NewReader(reader)
-		bufferedWriter := bufio.NewWriterSize(writer, 1)
-		handle := bufio.NewReadWriter(bufferedReader, bufferedWriter)
+		filePaths := []string{changedPath, unchangedPath}
+		s, errs := processInputFiles(context.Background(), filePaths, r, options)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
 
-		err := processFile(handle, mapping)
-		if err == nil {
-			t.Fatal("Expected an error but got none")
+		if s.changed != 1 || s.unchanged != 1 {
+			t.Errorf("Unexpected summary (got %+v)", s)
+		}
+
+		info, err := os.Stat(unchangedPath)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		if !os.SameFile(info, unchangedInfo) {
+			t.Error("Expected the unchanged file not to be rewritten")
+		}
+	})
+	t.Run("Streamed files", func(t *testing.T) {
+		changedPath, cleanupChanged := createTempFile(t, "hello.txt", "Hello world!")
+		defer cleanupChanged()
+
+		unchangedPath, cleanupUnchanged := createTempFile(t, "foo.txt", "foobar")
+		defer cleanupUnchanged()
+
+		unchangedInfo, err := os.Stat(unchangedPath)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		options := wordrow.FileOptions{Jobs: 2, StreamThreshold: 4}
+		filePaths := []string{changedPath, unchangedPath}
+		s, errs := processInputFiles(context.Background(), filePaths, r, options)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		if s.changed != 1 || s.unchanged != 1 {
+			t.Errorf("Unexpected summary (got %+v)", s)
+		}
+
+		content, err := ioutil.ReadFile(changedPath)
+		if err != nil {
+			t.Fatalf("Could not read the file (%s)", err)
+		}
+
+		if string(content) != "Hey world!" {
+			t.Errorf("Unexpected content (got '%s')", content)
+		}
+
+		info, err := os.Stat(unchangedPath)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		if !os.SameFile(info, unchangedInfo) {
+			t.Error("Expected the unchanged file not to be rewritten")
+		}
+	})
+	t.Run("Missing files", func(t *testing.T) {
+		filePaths := []string{"this-file-does-not-exist", "neither-does-this-one"}
+
+		s, errs := processInputFiles(context.Background(), filePaths, r, options)
+		if len(errs) != len(filePaths) {
+			t.Errorf("Unexpected number of errors (got %d)", len(errs))
+		}
+
+		if s.changed != 0 || s.unchanged != 0 {
+			t.Errorf("Unexpected summary (got %+v)", s)
 		}
 	})
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/internal/diff"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// A reporter is a function that writes a report on the `changes` to the
+// `content` of the input `name`, resulting in the `updatedContent`, to the
+// `output`.
+type reporter func(
+	output io.Writer,
+	name string,
+	content, updatedContent []byte,
+	changes []wordrow.Change,
+)
+
+// Get the reporter as configured by the `args`. If a report is requested the
+// changes are recorded in the `report` instead.
+func getReporter(args *cli.Arguments, report *wordrow.Report) reporter {
+	if args.Report != "" {
+		return func(_ io.Writer, name string, content, _ []byte, changes []wordrow.Change) {
+			report.Record(name, content, changes)
+		}
+	}
+
+	if args.Diff {
+		return getDiffReporter(args.DiffContext)
+	}
+
+	return reportChanges
+}
+
+// Check that the report requested by the `args` can be combined with the other
+// output, which for STDIN is the updated content. The report is written to
+// STDOUT, so it cannot be combined with a diff, nor with the updated content.
+//
+// The error will be set if the report cannot be combined with the output.
+func checkReportArgs(args *cli.Arguments, stdin bool) error {
+	if args.Report == "" {
+		return nil
+	}
+
+	if args.Diff {
+		return errors.New("The --report option cannot be used with --diff")
+	}
+
+	if stdin && !args.Check {
+		return errors.New("The --report option requires --check for STDIN")
+	}
+
+	return nil
+}
+
+// Write a report in the `format` on the changes recorded in the `report`, as
+// well as the `errors` and `warnings`, to the `output`.
+func writeReport(
+	output io.Writer,
+	format string,
+	report *wordrow.Report,
+	errors, warnings []error,
+) error {
+	switch format {
+	case cli.ReportJSON:
+		return writeJSONReport(output, report, errors, warnings)
+	case cli.ReportSARIF:
+		return writeSarifReport(output, report, errors, warnings)
+	}
+
+	return nil
+}
+
+// Format the change `c` to the `content` of the input `name` as a single line,
+// i.e. as `name:line:column: "from" -> "to"`.
+func formatChange(name string, content []byte, c wordrow.Change) string {
+	line, column := wordrow.Position(content, c.Start)
+	return fmt.Sprintf(
+		"%s:%d:%d: %q -> %q",
+		name,
+		line,
+		column,
+		c.Original,
+		c.Replacement,
+	)
+}
+
+// Report every change in `changes` on a separate line, see formatChange.
+func reportChanges(
+	output io.Writer,
+	name string,
+	content, _ []byte,
+	changes []wordrow.Change,
+) {
+	for _, change := range changes {
+		fmt.Fprintln(output, formatChange(name, content, change))
+	}
+}
+
+// Get a reporter that reports the changes as a unified diff with `context`
+// lines of context.
+func getDiffReporter(context int) reporter {
+	return func(
+		output io.Writer,
+		name string,
+		content, updatedContent []byte,
+		_ []wordrow.Change,
+	) {
+		output.Write(diff.Unified(name, content, updatedContent, context))
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// The jsonReport type represents a JSON report on a run of the program.
+type jsonReport struct {
+	// The inputs that were changed.
+	Files []jsonFile `json:"files"`
+
+	// The totals of the run.
+	Totals jsonTotals `json:"totals"`
+
+	// The errors that occurred during the run.
+	Errors []string `json:"errors"`
+
+	// The warnings that occurred during the run.
+	Warnings []string `json:"warnings"`
+}
+
+// The jsonFile type represents all replacements in a single input.
+type jsonFile struct {
+	// The name of the input.
+	Path string `json:"path"`
+
+	// The replacements in the input, in order.
+	Replacements []jsonReplacement `json:"replacements"`
+}
+
+// The jsonReplacement type represents a single replacement in an input.
+type jsonReplacement struct {
+	// The rule that caused the replacement.
+	Rule jsonRule `json:"rule"`
+
+	// The byte offset of the replaced text in the original input.
+	Offset int `json:"offset"`
+
+	// The line of the replaced text, starting at 1.
+	Line int `json:"line"`
+
+	// The column of the replaced text, starting at 1 and counted in characters.
+	Column int `json:"column"`
+
+	// The replaced text.
+	Original string `json:"original"`
+
+	// The text that replaced the replaced text.
+	Replacement string `json:"replacement"`
+}
+
+// The jsonRule type represents the mapping of a replacement.
+type jsonRule struct {
+	// The value that is replaced.
+	From string `json:"from"`
+
+	// The value that it is replaced with.
+	To string `json:"to"`
+
+	// The mapping file the rule is defined in, if any.
+	Source string `json:"source,omitempty"`
+
+	// The line in the mapping file the rule is defined at, if known.
+	Line int `json:"line,omitempty"`
+}
+
+// The jsonTotals type represents the totals of a run of the program.
+type jsonTotals struct {
+	// The number of inputs that were changed.
+	Files int `json:"files"`
+
+	// The number of replacements.
+	Replacements int `json:"replacements"`
+
+	// The number of errors.
+	Errors int `json:"errors"`
+
+	// The number of warnings.
+	Warnings int `json:"warnings"`
+}
+
+// Convert a list of errors into a list of their messages.
+func toMessages(errs []error) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return messages
+}
+
+// Create a JSON report on the `files`, `errors`, and `warnings`.
+func newJSONReport(files []wordrow.FileReport, errors, warnings []error) jsonReport {
+	report := jsonReport{
+		Files:    make([]jsonFile, 0, len(files)),
+		Errors:   toMessages(errors),
+		Warnings: toMessages(warnings),
+	}
+
+	for _, f := range files {
+		file := jsonFile{
+			Path:         f.Name,
+			Replacements: make([]jsonReplacement, len(f.Matches)),
+		}
+
+		for i, m := range f.Matches {
+			rule := jsonRule{From: m.Rule.From, To: m.Rule.To}
+			if m.Rule.Source != "" {
+				rule.Source, rule.Line = m.Rule.Source, m.Rule.Line
+			}
+
+			file.Replacements[i] = jsonReplacement{
+				Rule:        rule,
+				Offset:      m.Start,
+				Line:        m.Line,
+				Column:      m.Column,
+				Original:    m.Original,
+				Replacement: m.Replacement,
+			}
+		}
+
+		report.Files = append(report.Files, file)
+		report.Totals.Replacements += len(file.Replacements)
+	}
+
+	report.Totals.Files = len(report.Files)
+	report.Totals.Errors = len(report.Errors)
+	report.Totals.Warnings = len(report.Warnings)
+	return report
+}
+
+// Write a JSON report on the changes recorded in the `report`, as well as the
+// `errors` and `warnings`, to the `output`.
+func writeJSONReport(
+	output io.Writer,
+	report *wordrow.Report,
+	errors, warnings []error,
+) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newJSONReport(report.Files(), errors, warnings))
+}
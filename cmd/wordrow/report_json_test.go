@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+func TestWriteJSONReport(t *testing.T) {
+	t.Run("Replacements", func(t *testing.T) {
+		mapping := wordrow.Rule{From: "hello", To: "hey", Source: "map.csv", Line: 2}
+		content := []byte("Hi!\nOh, hello <world>")
+		changes := []wordrow.Change{
+			{Rule: mapping, Start: 8, End: 13, Original: "hello", Replacement: "hey"},
+		}
+
+		r := new(wordrow.Report)
+		r.Record("foo.txt", content, changes)
+
+		output := new(bytes.Buffer)
+		warnings := []error{errors.New("Something is off")}
+		if err := writeJSONReport(output, r, nil, warnings); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		expected := `{
+  "files": [
+    {
+      "path": "foo.txt",
+      "replacements": [
+        {
+          "rule": {
+            "from": "hello",
+            "to": "hey",
+            "source": "map.csv",
+            "line": 2
+          },
+          "offset": 8,
+          "line": 2,
+          "column": 5,
+          "original": "hello",
+          "replacement": "hey"
+        }
+      ]
+    }
+  ],
+  "totals": {
+    "files": 1,
+    "replacements": 1,
+    "errors": 0,
+    "warnings": 1
+  },
+  "errors": [],
+  "warnings": [
+    "Something is off"
+  ]
+}
+`
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Inline rule", func(t *testing.T) {
+		mapping := wordrow.Rule{From: "hello", To: "hey", Line: 1}
+		changes := []wordrow.Change{
+			{Rule: mapping, Start: 0, End: 5, Original: "hello", Replacement: "hey"},
+		}
+
+		r := new(wordrow.Report)
+		r.Record("foo.txt", []byte("hello"), changes)
+
+		output := new(bytes.Buffer)
+		if err := writeJSONReport(output, r, nil, nil); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		expected := `"rule": {
+            "from": "hello",
+            "to": "hey"
+          },`
+		if !bytes.Contains(output.Bytes(), []byte(expected)) {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Nothing", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		if err := writeJSONReport(output, new(wordrow.Report), nil, nil); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		expected := `{
+  "files": [],
+  "totals": {
+    "files": 0,
+    "replacements": 0,
+    "errors": 0,
+    "warnings": 0
+  },
+  "errors": [],
+  "warnings": []
+}
+`
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// The URI of the SARIF 2.1.0 JSON schema.
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// The version of SARIF used for a SARIF report.
+const sarifVersion = "2.1.0"
+
+// The URI with information about the program.
+const informationURI = "https://github.com/ericcornelissen/wordrow"
+
+// The sarifLog type represents a SARIF report on a run of the program.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// The sarifRun type represents a single run of the program.
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+	ColumnKind  string            `json:"columnKind"`
+}
+
+// The sarifTool type represents the program.
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// The sarifDriver type represents the program and its rules.
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// The sarifRule type represents a single mapping.
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription sarifMessage  `json:"shortDescription"`
+	FullDescription  *sarifMessage `json:"fullDescription,omitempty"`
+}
+
+// The sarifInvocation type represents the invocation of the program, including
+// the errors and warnings that occurred.
+type sarifInvocation struct {
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	Notifications       []sarifNotification `json:"toolExecutionNotifications"`
+}
+
+// The sarifNotification type represents a single error or warning.
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+// The sarifResult type represents a single replacement in an input.
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level,omitempty"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes"`
+}
+
+// The sarifMessage type represents a plain text message.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// The sarifLocation type represents a location in an input.
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// The sarifPhysicalLocation type represents a region of an input.
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// The sarifArtifactLocation type represents an input.
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// The sarifRegion type represents a region of an input. The columns are
+// counted in characters.
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+	ByteOffset  int `json:"byteOffset"`
+	ByteLength  int `json:"byteLength"`
+}
+
+// The sarifFix type represents the fix of a single replacement.
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+// The sarifArtifactChange type represents the changes to an input.
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+// The sarifReplacement type represents a region of an input and the text that
+// replaces it.
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// Get the identifier of the SARIF rule for the mapping `m`. For a mapping from
+// a mapping file this is the file and line it is defined at followed by the
+// value that is replaced, e.g. "map.csv:3:cat", as multiple mappings may be
+// defined on one line. Otherwise it is based on the value that is replaced
+// only, e.g. "inline:cat".
+func getRuleID(m wordrow.Rule) string {
+	if m.Source == "" {
+		return fmt.Sprintf("inline:%s", m.From)
+	}
+
+	return fmt.Sprintf("%s:%d:%s", filepath.ToSlash(m.Source), m.Line, m.From)
+}
+
+// Get the SARIF level of the results of the mapping `m`, based on its severity.
+// It is empty if the mapping has no severity.
+func getLevel(m wordrow.Rule) string {
+	if m.Severity == "info" {
+		return "note"
+	}
+
+	return m.Severity
+}
+
+// Create the SARIF rule with identifier `id` for the mapping `m`.
+func newSarifRule(id string, m wordrow.Rule) sarifRule {
+	rule := sarifRule{
+		ID: id,
+		ShortDescription: sarifMessage{
+			Text: fmt.Sprintf("Replace with '%s'", m.To),
+		},
+	}
+
+	if m.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: m.Description}
+	}
+
+	return rule
+}
+
+// Get the URI of the input `name`.
+func getURI(name string) string {
+	uri := url.URL{Path: filepath.ToSlash(name)}
+	if filepath.IsAbs(name) {
+		uri.Scheme = "file"
+	}
+
+	return uri.String()
+}
+
+// Convert a list of errors into a list of notifications at `level`.
+func toNotifications(errs []error, level string) []sarifNotification {
+	notifications := make([]sarifNotification, len(errs))
+	for i, err := range errs {
+		notifications[i] = sarifNotification{
+			Level:   level,
+			Message: sarifMessage{Text: err.Error()},
+		}
+	}
+
+	return notifications
+}
+
+// Create a SARIF result for the match `m` to the input at `uri`, caused by
+// the rule with identifier `ruleID` at `ruleIndex`.
+func newSarifResult(
+	uri string,
+	m wordrow.Match,
+	ruleID string,
+	ruleIndex int,
+) sarifResult {
+	location := sarifArtifactLocation{URI: uri}
+	region := sarifRegion{
+		StartLine:   m.Line,
+		StartColumn: m.Column,
+		EndLine:     m.EndLine,
+		EndColumn:   m.EndColumn,
+		ByteOffset:  m.Start,
+		ByteLength:  m.End - m.Start,
+	}
+
+	message := fmt.Sprintf("Replace '%s' with '%s'", m.Original, m.Replacement)
+	return sarifResult{
+		RuleID:    ruleID,
+		RuleIndex: ruleIndex,
+		Level:     getLevel(m.Rule),
+		Message:   sarifMessage{Text: message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: location,
+				Region:           region,
+			},
+		}},
+		Fixes: []sarifFix{{
+			Description: sarifMessage{Text: message},
+			ArtifactChanges: []sarifArtifactChange{{
+				ArtifactLocation: location,
+				Replacements: []sarifReplacement{{
+					DeletedRegion:   region,
+					InsertedContent: sarifMessage{Text: m.Replacement},
+				}},
+			}},
+		}},
+	}
+}
+
+// Create a SARIF report on the `files`, `errors`, and `warnings`. Every
+// mapping that caused a change becomes a rule, mappings defined on the same
+// line of a mapping file share a rule.
+func newSarifLog(files []wordrow.FileReport, errors, warnings []error) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "wordrow",
+				Version:        versionString,
+				InformationURI: informationURI,
+				Rules:          make([]sarifRule, 0),
+			},
+		},
+		Invocations: []sarifInvocation{{
+			ExecutionSuccessful: len(errors) == 0,
+			Notifications: append(
+				toNotifications(errors, "error"),
+				toNotifications(warnings, "warning")...,
+			),
+		}},
+		Results:    make([]sarifResult, 0),
+		ColumnKind: "unicodeCodePoints",
+	}
+
+	ruleIndices := make(map[string]int)
+	for _, f := range files {
+		uri := getURI(f.Name)
+		for _, m := range f.Matches {
+			id := getRuleID(m.Rule)
+			index, ok := ruleIndices[id]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndices[id] = index
+				rule := newSarifRule(id, m.Rule)
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			}
+
+			run.Results = append(run.Results, newSarifResult(uri, m, id, index))
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+}
+
+// Write a SARIF report on the changes recorded in the `report`, as well as the
+// `errors` and `warnings`, to the `output`.
+func writeSarifReport(
+	output io.Writer,
+	report *wordrow.Report,
+	errors, warnings []error,
+) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newSarifLog(report.Files(), errors, warnings))
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Validate the SARIF report `report` against the vendored SARIF JSON schema.
+func validateSarif(t *testing.T, report []byte) {
+	t.Helper()
+
+	schema, err := ioutil.ReadFile(filepath.Join("testdata", "sarif-schema-2.1.0.json"))
+	if err != nil {
+		t.Fatalf("Could not read the SARIF schema (%s)", err)
+	}
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(schema),
+		gojsonschema.NewBytesLoader(report),
+	)
+	if err != nil {
+		t.Fatalf("Could not validate the report (%s)", err)
+	}
+
+	for _, e := range result.Errors() {
+		t.Errorf("Invalid report: %s", e)
+	}
+}
+
+func TestGetRuleID(t *testing.T) {
+	t.Run("Mapping file", func(t *testing.T) {
+		mapping := wordrow.Rule{From: "cat", To: "dog", Source: "animals.csv", Line: 3}
+		if id := getRuleID(mapping); id != "animals.csv:3:cat" {
+			t.Errorf("Unexpected rule id (got '%s')", id)
+		}
+	})
+	t.Run("Inline mapping", func(t *testing.T) {
+		mapping := wordrow.Rule{From: "cat", To: "dog"}
+		if id := getRuleID(mapping); id != "inline:cat" {
+			t.Errorf("Unexpected rule id (got '%s')", id)
+		}
+	})
+}
+
+func TestGetLevel(t *testing.T) {
+	cases := map[string]string{"": "", "error": "error", "warning": "warning", "info": "note"}
+	for severity, expected := range cases {
+		mapping := wordrow.Rule{From: "cat", To: "dog", Severity: severity}
+		if level := getLevel(mapping); level != expected {
+			t.Errorf("Unexpected level for '%s' (got '%s')", severity, level)
+		}
+	}
+}
+
+func TestGetURI(t *testing.T) {
+	t.Run("Relative path", func(t *testing.T) {
+		uri := getURI(filepath.Join("docs", "my file.md"))
+		if uri != "docs/my%20file.md" {
+			t.Errorf("Unexpected URI (got '%s')", uri)
+		}
+	})
+	t.Run("Absolute path", func(t *testing.T) {
+		path, err := filepath.Abs("foo.txt")
+		if err != nil {
+			t.Fatalf("Could not get an absolute path (%s)", err)
+		}
+
+		uri := getURI(path)
+		if uri != "file://"+filepath.ToSlash(path) && uri != "file:///"+filepath.ToSlash(path) {
+			t.Errorf("Unexpected URI (got '%s')", uri)
+		}
+	})
+}
+
+func TestWriteSarifReport(t *testing.T) {
+	cat := wordrow.Rule{From: "cat", To: "dog", Source: "animals.csv", Line: 1}
+	kitten := wordrow.Rule{From: "kitten", To: "dog", Source: "animals.csv", Line: 1}
+	hello := wordrow.Rule{From: "hello", To: "hey"}
+
+	content := []byte("Hello, cat!\nA kitten and a cat.\n")
+	changes := []wordrow.Change{
+		{Rule: hello, Start: 0, End: 5, Original: "Hello", Replacement: "Hey"},
+		{Rule: cat, Start: 7, End: 10, Original: "cat", Replacement: "dog"},
+		{Rule: kitten, Start: 14, End: 20, Original: "kitten", Replacement: "dog"},
+		{Rule: cat, Start: 27, End: 30, Original: "cat", Replacement: "dog"},
+	}
+
+	r := new(wordrow.Report)
+	r.Record("foo.txt", content, changes)
+
+	t.Run("Valid", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		warnings := []error{errors.New("Something is off")}
+		if err := writeSarifReport(output, r, nil, warnings); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		validateSarif(t, output.Bytes())
+	})
+	t.Run("Valid without changes", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		errs := []error{errors.New("Something went wrong")}
+		if err := writeSarifReport(output, new(wordrow.Report), errs, nil); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		validateSarif(t, output.Bytes())
+	})
+	t.Run("Rules", func(t *testing.T) {
+		log := newSarifLog(r.Files(), nil, nil)
+		rules := log.Runs[0].Tool.Driver.Rules
+
+		expected := []string{"inline:hello", "animals.csv:1:cat", "animals.csv:1:kitten"}
+		if len(rules) != len(expected) {
+			t.Fatalf("Unexpected number of rules (got %d)", len(rules))
+		}
+
+		for i, id := range expected {
+			if rules[i].ID != id {
+				t.Errorf("Unexpected rule at %d (got '%s')", i, rules[i].ID)
+			}
+		}
+	})
+	t.Run("Results", func(t *testing.T) {
+		log := newSarifLog(r.Files(), nil, nil)
+		results := log.Runs[0].Results
+		if len(results) != len(changes) {
+			t.Fatalf("Unexpected number of results (got %d)", len(results))
+		}
+
+		result := results[2]
+		if result.RuleID != "animals.csv:1:kitten" || result.RuleIndex != 2 {
+			t.Errorf("Unexpected rule (got '%s' at %d)", result.RuleID, result.RuleIndex)
+		}
+
+		expected := sarifRegion{
+			StartLine:   2,
+			StartColumn: 3,
+			EndLine:     2,
+			EndColumn:   9,
+			ByteOffset:  14,
+			ByteLength:  6,
+		}
+
+		region := result.Locations[0].PhysicalLocation.Region
+		if region != expected {
+			t.Errorf("Unexpected region (got %+v)", region)
+		}
+
+		replacement := result.Fixes[0].ArtifactChanges[0].Replacements[0]
+		if replacement.DeletedRegion != expected {
+			t.Errorf("Unexpected deleted region (got %+v)", replacement.DeletedRegion)
+		}
+
+		if replacement.InsertedContent.Text != "dog" {
+			t.Errorf("Unexpected inserted content (got '%s')", replacement.InsertedContent.Text)
+		}
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		horse := wordrow.Rule{
+			From:        "horse",
+			To:          "zebra",
+			Description: "Prefer stripes",
+			Severity:    "info",
+		}
+
+		r := new(wordrow.Report)
+		r.Record("bar.txt", []byte("horse"), []wordrow.Change{
+			{Rule: horse, Start: 0, End: 5, Original: "horse", Replacement: "zebra"},
+		})
+
+		output := new(bytes.Buffer)
+		if err := writeSarifReport(output, r, nil, nil); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		validateSarif(t, output.Bytes())
+
+		log := newSarifLog(r.Files(), nil, nil)
+		rule := log.Runs[0].Tool.Driver.Rules[0]
+		if rule.FullDescription == nil || rule.FullDescription.Text != horse.Description {
+			t.Errorf("Unexpected full description (got %+v)", rule.FullDescription)
+		}
+
+		if level := log.Runs[0].Results[0].Level; level != "note" {
+			t.Errorf("Unexpected level (got '%s')", level)
+		}
+	})
+	t.Run("Rules on one line", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "map.json", `{"cat":"dog","kitten":"puppy"}`)
+		defer cleanup()
+
+		mapping, errs := getMapping(&cli.Arguments{MapFiles: []string{path}})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (%s)", errs)
+		}
+
+		replacer, _ := getReplacer(mapping, &cli.Arguments{})
+		content := []byte("A cat and a kitten")
+		_, changes := replacer.Replace(content)
+
+		r := new(wordrow.Report)
+		r.Record("bar.txt", content, changes)
+
+		log := newSarifLog(r.Files(), nil, nil)
+		rules := log.Runs[0].Tool.Driver.Rules
+		if len(rules) != 2 || rules[0].ID == rules[1].ID {
+			t.Fatalf("Unexpected rules (got %+v)", rules)
+		}
+
+		results := log.Runs[0].Results
+		for i, to := range []string{"dog", "puppy"} {
+			rule := rules[results[i].RuleIndex]
+			if rule.ID != results[i].RuleID {
+				t.Errorf("Unexpected rule for result %d (got '%s')", i, rule.ID)
+			}
+
+			if rule.ShortDescription.Text != fmt.Sprintf("Replace with '%s'", to) {
+				t.Errorf("Unexpected description for result %d (got '%s')", i, rule.ShortDescription.Text)
+			}
+		}
+	})
+	t.Run("Notifications", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		errs := []error{errors.New("Something went wrong")}
+		warnings := []error{errors.New("Something is off")}
+		if err := writeSarifReport(output, r, errs, warnings); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		var log sarifLog
+		if err := json.Unmarshal(output.Bytes(), &log); err != nil {
+			t.Fatalf("Could not parse the report (%s)", err)
+		}
+
+		invocation := log.Runs[0].Invocations[0]
+		if invocation.ExecutionSuccessful {
+			t.Error("Expected the execution to be unsuccessful")
+		}
+
+		notifications := invocation.Notifications
+		if len(notifications) != 2 {
+			t.Fatalf("Unexpected number of notifications (got %d)", len(notifications))
+		}
+
+		if notifications[0].Level != "error" || notifications[1].Level != "warning" {
+			t.Errorf("Unexpected levels (got '%s' and '%s')", notifications[0].Level, notifications[1].Level)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+func TestGetReporter(t *testing.T) {
+	content := []byte("Hello world!\n")
+	updatedContent := []byte("Hey world!\n")
+	changes := []wordrow.Change{
+		{Start: 0, End: 5, Original: "Hello", Replacement: "Hey"},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		output := new(bytes.Buffer)
+
+		report := getReporter(&cli.Arguments{}, new(wordrow.Report))
+		report(output, "foo.txt", content, updatedContent, changes)
+
+		expected := "foo.txt:1:1: \"Hello\" -> \"Hey\"\n"
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Diff", func(t *testing.T) {
+		output := new(bytes.Buffer)
+
+		report := getReporter(&cli.Arguments{Diff: true, DiffContext: 3}, new(wordrow.Report))
+		report(output, "foo.txt", content, updatedContent, changes)
+
+		expected := "--- a/foo.txt\n+++ b/foo.txt\n@@ -1,1 +1,1 @@\n-Hello world!\n+Hey world!\n"
+		if output.String() != expected {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+	})
+	t.Run("Report", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		r := new(wordrow.Report)
+
+		report := getReporter(&cli.Arguments{Diff: true, Report: cli.ReportJSON}, r)
+		report(output, "foo.txt", content, updatedContent, changes)
+
+		if output.Len() != 0 {
+			t.Errorf("Unexpected output (got '%s')", output)
+		}
+
+		if files := r.Files(); len(files) != 1 {
+			t.Errorf("Unexpected number of files (got %d)", len(files))
+		}
+	})
+}
+
+func TestCheckReportArgs(t *testing.T) {
+	valid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{}, stdin: false},
+		{args: cli.Arguments{Diff: true}, stdin: true},
+		{args: cli.Arguments{Report: cli.ReportJSON}, stdin: false},
+		{args: cli.Arguments{Report: cli.ReportJSON, Check: true}, stdin: false},
+		{args: cli.Arguments{Report: cli.ReportSARIF, Check: true}, stdin: true},
+	}
+
+	for _, c := range valid {
+		if err := checkReportArgs(&c.args, c.stdin); err != nil {
+			t.Errorf("Unexpected error for %+v (got '%s')", c, err)
+		}
+	}
+
+	invalid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{Report: cli.ReportJSON, Diff: true}, stdin: false},
+		{args: cli.Arguments{Report: cli.ReportJSON, Check: true, Diff: true}, stdin: true},
+		{args: cli.Arguments{Report: cli.ReportJSON}, stdin: true},
+	}
+
+	for _, c := range invalid {
+		if err := checkReportArgs(&c.args, c.stdin); err == nil {
+			t.Errorf("Expected an error for %+v", c)
+		}
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	content := []byte("Hello\nworld!")
+	change := wordrow.Change{
+		Start:       6,
+		End:         11,
+		Original:    "world",
+		Replacement: "planet",
+	}
+
+	result := formatChange("foo.txt", content, change)
+
+	expected := `foo.txt:2:1: "world" -> "planet"`
+	if result != expected {
+		t.Errorf("Unexpected result (got '%s')", result)
+	}
+}
@@ -1,19 +1,32 @@
 package main
 
-import "os"
+import (
+	"os"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/internal/logger"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
 
 // Handler represents a function to handle a (string) value and return an error.
 type handler func(value string) error
 
-// Drains `n` items from channel `ch` and returns all non-null errors.
-func drain(ch chan error, n int) (errs []error) {
-	for i := 0; i < n; i++ {
-		if err := <-ch; err != nil {
-			errs = append(errs, err)
+// Summarize the `results`, returning a summary of the successful results as
+// well as all non-null errors in order.
+func summarize(results []wordrow.FileResult) (s summary, errs []error) {
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			errs = append(errs, r.Err)
+		case r.Changed:
+			s.changed++
+		default:
+			s.unchanged++
 		}
 	}
 
-	return errs
+	return s, errs
 }
 
 // ForEach executes a handler for each of the provided values. Any error that
@@ -41,12 +54,38 @@ func hasStdin() bool {
 	return (stdin.Mode() & os.ModeNamedPipe) != 0
 }
 
-// Invert the map `m`. I.e. swap each (key, value)-pair in the map.
-func invert(m map[string]string) map[string]string {
-	inverted := make(map[string]string, len(m))
-	for key, value := range m {
-		inverted[value] = key
+// Get the warning for an input file `path` that is skipped because it refers to
+// the same file as the `original` path.
+func duplicateWarning(path, original string) error {
+	if path == original {
+		return errors.Newf("Skipping '%s' as it is specified more than once", path)
+	}
+
+	return errors.Newf("Skipping '%s' as it is the same file as '%s'", path, original)
+}
+
+// Get the options to resolve input files with as configured by the `args`. A
+// warning is logged for every input file that is skipped as a duplicate.
+func getWalkOptions(args *cli.Arguments) wordrow.WalkOptions {
+	symlinks := wordrow.SymlinksSkip
+	switch args.Symlinks {
+	case cli.SymlinksFollow:
+		symlinks = wordrow.SymlinksFollow
+	case cli.SymlinksError:
+		symlinks = wordrow.SymlinksError
 	}
 
-	return inverted
+	return wordrow.WalkOptions{
+		Include:           args.Includes,
+		Exclude:           args.Excludes,
+		Extensions:        args.Extensions,
+		MaxDepth:          args.MaxDepth,
+		Symlinks:          symlinks,
+		NoDefaultExcludes: args.NoDefaultExcludes,
+		NoIgnore:          args.NoIgnore,
+		ResolveSymlinks:   args.ResolveSymlinks,
+		OnDuplicate: func(path, original string) {
+			logger.Warning(duplicateWarning(path, original))
+		},
+	}
 }
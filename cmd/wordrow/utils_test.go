@@ -1,12 +1,17 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
 
 func TestInvert(t *testing.T) {
-	t.Run("empty map", func(t *testing.T) {
-		mapping := make(map[string]string)
+	t.Run("empty mapping", func(t *testing.T) {
+		var mapping []wordrow.Rule
 
-		result := invert(mapping)
+		result := wordrow.InvertRules(mapping)
 		if len(result) != 0 {
 			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
 		}
@@ -15,42 +20,124 @@ func TestInvert(t *testing.T) {
 		from0, to0 := "foo", "bar"
 		from1, to1 := "hello", "world"
 
-		mapping := make(map[string]string, 2)
-		mapping[from0] = to0
-		mapping[from1] = to1
+		mapping := []wordrow.Rule{
+			{From: from0, To: to0},
+			{From: from1, To: to1},
+		}
 
-		result := invert(mapping)
+		result := wordrow.InvertRules(mapping)
 		if len(result) != len(mapping) {
 			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
 		}
 
-		if result[to0] != from0 {
-			t.Errorf("Unexpected value for first key (got '%s')", result[to0])
+		if result[0].From != to0 || result[0].To != from0 {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", result[0].From, result[0].To)
 		}
 
-		if result[to1] != from1 {
-			t.Errorf("Unexpected value for second key (got '%s')", result[to1])
+		if result[1].From != to1 || result[1].To != from1 {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", result[1].From, result[1].To)
 		}
 	})
 	t.Run("works with mirrored mapping", func(t *testing.T) {
 		from0, to0 := "foo", "bar"
 		from1, to1 := "bar", "foo"
 
-		mapping := make(map[string]string, 2)
-		mapping[from0] = to0
-		mapping[from1] = to1
+		mapping := []wordrow.Rule{
+			{From: from0, To: to0},
+			{From: from1, To: to1},
+		}
 
-		result := invert(mapping)
+		result := wordrow.InvertRules(mapping)
 		if len(result) != len(mapping) {
 			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
 		}
 
-		if result[to0] != from0 {
-			t.Errorf("Unexpected value for first key (got '%s')", result[to0])
+		if result[0].From != to0 || result[0].To != from0 {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", result[0].From, result[0].To)
+		}
+
+		if result[1].From != to1 || result[1].To != from1 {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", result[1].From, result[1].To)
+		}
+	})
+	t.Run("many-to-one mapping", func(t *testing.T) {
+		mapping := []wordrow.Rule{
+			{From: "foo", To: "bar"},
+			{From: "baz", To: "bar"},
+		}
+
+		result := wordrow.InvertRules(mapping)
+		if len(result) != 1 {
+			t.Fatalf("Unexpected size of inverted map (got %d)", len(result))
+		}
+
+		if result[0].From != "bar" || result[0].To != "baz" {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", result[0].From, result[0].To)
+		}
+	})
+	t.Run("keeps the source", func(t *testing.T) {
+		mapping := []wordrow.Rule{
+			{From: "foo", To: "bar", Source: "map.csv", Line: 3},
+		}
+
+		result := wordrow.InvertRules(mapping)
+		if result[0].Source != "map.csv" || result[0].Line != 3 {
+			t.Errorf("Unexpected source (got '%s' line %d)", result[0].Source, result[0].Line)
+		}
+	})
+}
+
+func TestGetWalkOptions(t *testing.T) {
+	t.Run("Filters", func(t *testing.T) {
+		args := &cli.Arguments{
+			Includes:          []string{"*.md"},
+			Excludes:          []string{"vendor"},
+			Extensions:        []string{"txt"},
+			MaxDepth:          2,
+			NoDefaultExcludes: true,
+			NoIgnore:          true,
+			ResolveSymlinks:   true,
 		}
 
-		if result[to1] != from1 {
-			t.Errorf("Unexpected value for second key (got '%s')", result[to1])
+		options := getWalkOptions(args)
+		if options.Include[0] != "*.md" || options.Exclude[0] != "vendor" || options.Extensions[0] != "txt" {
+			t.Errorf("Unexpected filters (got %+v)", options)
+		}
+
+		if options.MaxDepth != 2 || !options.NoDefaultExcludes || !options.NoIgnore || !options.ResolveSymlinks {
+			t.Errorf("Unexpected options (got %+v)", options)
 		}
 	})
+	t.Run("Symlinks", func(t *testing.T) {
+		policies := map[string]wordrow.SymlinkPolicy{
+			cli.SymlinksFollow: wordrow.SymlinksFollow,
+			cli.SymlinksSkip:   wordrow.SymlinksSkip,
+			cli.SymlinksError:  wordrow.SymlinksError,
+		}
+
+		for name, expected := range policies {
+			options := getWalkOptions(&cli.Arguments{Symlinks: name})
+			if options.Symlinks != expected {
+				t.Errorf("Unexpected policy for '%s' (got %d)", name, options.Symlinks)
+			}
+		}
+	})
+	t.Run("Duplicates", func(t *testing.T) {
+		options := getWalkOptions(&cli.Arguments{})
+		if options.OnDuplicate == nil {
+			t.Error("Duplicates should be warned about")
+		}
+	})
+}
+
+func TestDuplicateWarning(t *testing.T) {
+	err := duplicateWarning("foo.md", "foo.md")
+	if err.Error() != "Skipping 'foo.md' as it is specified more than once" {
+		t.Errorf("Unexpected warning for a repeated path (got '%s')", err)
+	}
+
+	err = duplicateWarning("link.md", "foo.md")
+	if err.Error() != "Skipping 'link.md' as it is the same file as 'foo.md'" {
+		t.Errorf("Unexpected warning for a different path (got '%s')", err)
+	}
 }
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+	"github.com/ericcornelissen/wordrow/internal/logger"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// The interval at which the input files and mapping files are checked for
+// changes in watch mode.
+const watchInterval = 500 * time.Millisecond
+
+// Check that watching files, as requested by the `args`, can be combined with
+// the other modes. Only files are watched, and they are watched to update them,
+// so it cannot be combined with processing STDIN, nor with modes that do not
+// update files.
+//
+// The error will be set if watching files cannot be combined with the other
+// modes.
+func checkWatchArgs(args *cli.Arguments, stdin bool) error {
+	if !args.Watch {
+		return nil
+	}
+
+	switch {
+	case stdin:
+		return errors.New("The --watch flag cannot be used for STDIN")
+	case args.Check:
+		return errors.New("The --watch flag cannot be used with --check")
+	case args.Diff:
+		return errors.New("The --watch flag cannot be used with --diff")
+	case args.DryRun:
+		return errors.New("The --watch flag cannot be used with --dry-run")
+	}
+
+	return nil
+}
+
+// Get a channel that is closed once the program is interrupted or terminated.
+func interrupted() <-chan struct{} {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		close(done)
+	}()
+
+	return done
+}
+
+// Get the paths of the mapping files specified by the `args`.
+func getMapFilePaths(args *cli.Arguments) []string {
+	filePaths := make([]string, len(args.MapFiles))
+	for i, argument := range args.MapFiles {
+		filePaths[i], _ = parseMapFileArgument(argument)
+	}
+
+	return filePaths
+}
+
+// Log all `errs` at the error level.
+func logErrors(errs []error) {
+	for _, err := range errs {
+		logger.Error(err)
+	}
+}
+
+// Log all `errs` at the warning level.
+func logWarnings(errs []error) {
+	for _, err := range errs {
+		logger.Warning(err)
+	}
+}
+
+// Get the options to walk directories with in the fs package for the `options`.
+func toFSWalkOptions(options wordrow.WalkOptions) fs.WalkOptions {
+	symlinks := map[wordrow.SymlinkPolicy]fs.SymlinkPolicy{
+		wordrow.SymlinksSkip:   fs.SymlinksSkip,
+		wordrow.SymlinksFollow: fs.SymlinksFollow,
+		wordrow.SymlinksError:  fs.SymlinksError,
+	}
+
+	return fs.WalkOptions{
+		Include:           options.Include,
+		Exclude:           options.Exclude,
+		Extensions:        options.Extensions,
+		MaxDepth:          options.MaxDepth,
+		Symlinks:          symlinks[options.Symlinks],
+		NoDefaultExcludes: options.NoDefaultExcludes,
+		NoIgnore:          options.NoIgnore,
+		ResolveSymlinks:   options.ResolveSymlinks,
+		OnDuplicate:       options.OnDuplicate,
+	}
+}
+
+// Log the `errs` at the warning level, except for those that were logged
+// before according to `logged`, which is updated accordingly.
+func logNewWarnings(errs []error, logged map[string]bool) {
+	for _, err := range errs {
+		if !logged[err.Error()] {
+			logged[err.Error()] = true
+			logger.Warning(err)
+		}
+	}
+}
+
+// Remove the `excluded` paths from `filePaths`.
+func withoutPaths(filePaths []string, excluded map[string]bool) []string {
+	var result []string
+	for _, filePath := range filePaths {
+		if !excluded[filepath.Clean(filePath)] {
+			result = append(result, filePath)
+		}
+	}
+
+	return result
+}
+
+// Update the input files specified by the `args` using the Replacer `r`, and
+// keep doing so whenever they change, until `done` is closed. The input globs
+// are resolved again every `interval`, so newly created files matching them are
+// updated as well. If a mapping file changes the mapping is reloaded into a new
+// Replacer and all input files are updated again. Mapping files are watched
+// regardless of the filters of the `args`, and are never updated, even if they
+// match an input glob. Changes made by the program itself are ignored, but
+// changes made to a file after it was updated are not.
+//
+// Errors and warnings are logged as they occur, though warnings about resolving
+// the input files are logged only once. If a report is requested the changes
+// made to the input files are recorded in the `report`.
+func watchFiles(
+	args *cli.Arguments,
+	r *wordrow.Replacer,
+	report *wordrow.Report,
+	interval time.Duration,
+	done <-chan struct{},
+) {
+	mapFiles := getMapFilePaths(args)
+	isMapFile := make(map[string]bool, len(mapFiles))
+	for _, filePath := range mapFiles {
+		isMapFile[filepath.Clean(filePath)] = true
+	}
+
+	logged := make(map[string]bool)
+	walkOptions := getWalkOptions(args)
+	walkOptions.OnDuplicate = func(path, original string) {
+		logNewWarnings([]error{duplicateWarning(path, original)}, logged)
+	}
+
+	w := fs.NewWatcher(interval, toFSWalkOptions(walkOptions), args.InputFiles...)
+	defer w.Close()
+
+	w.AddFiles(mapFiles...)
+
+	options := getFileOptions(args, report)
+
+	logger.Info("Watching for changes, press Ctrl+C to stop")
+	for first := true; ; first = false {
+		filePaths, errs := w.Poll()
+		logNewWarnings(errs, logged)
+
+		if !first && len(filePaths) != len(withoutPaths(filePaths, isMapFile)) {
+			logger.Info("Reloading the mapping")
+			mapping, errs := getMapping(args)
+			logWarnings(errs)
+
+			r, _ = getReplacer(mapping, args)
+			filePaths = w.Files()
+		}
+
+		filePaths = withoutPaths(filePaths, isMapFile)
+		if len(filePaths) > 0 {
+			results := r.ReplaceFiles(context.Background(), filePaths, options)
+			for i := range results {
+				logResult(&results[i])
+				w.Ignore(results[i].Path, results[i].Written)
+			}
+
+			s, errs := summarize(results)
+			logErrors(errs)
+
+			logger.Infof(
+				"%d file(s) changed, %d file(s) unchanged",
+				s.changed,
+				s.unchanged,
+			)
+		}
+
+		if !w.Wait(done) {
+			return
+		}
+	}
+}
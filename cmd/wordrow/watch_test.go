@@ -0,0 +1,139 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
+)
+
+// Wait until the content of the file at `path` equals `expected`, or fail the
+// test if it doesn't within a few seconds.
+func waitForContent(t *testing.T, path, expected string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		content, err := ioutil.ReadFile(path)
+		if err == nil && string(content) == expected {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected content of '%s' (got '%s')", path, content)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Write `content` to the file at `path`.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Could not write the file (%s)", err)
+	}
+}
+
+func TestCheckWatchArgs(t *testing.T) {
+	valid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{Check: true}, stdin: true},
+		{args: cli.Arguments{Watch: true}, stdin: false},
+		{args: cli.Arguments{Watch: true, Report: cli.ReportJSON}, stdin: false},
+	}
+
+	for _, c := range valid {
+		if err := checkWatchArgs(&c.args, c.stdin); err != nil {
+			t.Errorf("Unexpected error for %+v (got '%s')", c, err)
+		}
+	}
+
+	invalid := []struct {
+		args  cli.Arguments
+		stdin bool
+	}{
+		{args: cli.Arguments{Watch: true}, stdin: true},
+		{args: cli.Arguments{Watch: true, Check: true}, stdin: false},
+		{args: cli.Arguments{Watch: true, Diff: true}, stdin: false},
+		{args: cli.Arguments{Watch: true, DryRun: true}, stdin: false},
+	}
+
+	for _, c := range invalid {
+		if err := checkWatchArgs(&c.args, c.stdin); err == nil {
+			t.Errorf("Expected an error for %+v", c)
+		}
+	}
+}
+
+func TestGetMapFilePaths(t *testing.T) {
+	args := &cli.Arguments{MapFiles: []string{"foo.csv", "bar:md"}}
+
+	filePaths := getMapFilePaths(args)
+	if len(filePaths) != 2 || filePaths[0] != "foo.csv" || filePaths[1] != "bar" {
+		t.Errorf("Unexpected paths (got %q)", filePaths)
+	}
+}
+
+func TestWithoutPaths(t *testing.T) {
+	excluded := map[string]bool{"foo.csv": true}
+
+	filePaths := withoutPaths([]string{"./foo.csv", "bar.txt"}, excluded)
+	if len(filePaths) != 1 || filePaths[0] != "bar.txt" {
+		t.Errorf("Unexpected paths (got %q)", filePaths)
+	}
+}
+
+func TestWatchFiles(t *testing.T) {
+	inputPath, cleanup := createTempFile(t, "foo.txt", "A cat")
+	defer cleanup()
+
+	dir := filepath.Dir(inputPath)
+	mapPath := filepath.Join(dir, "map.csv")
+	writeFile(t, mapPath, "cat,dog")
+
+	args := &cli.Arguments{
+		InputFiles: []string{filepath.Join(dir, "*")},
+		MapFiles:   []string{mapPath},
+		Extensions: []string{"txt"},
+	}
+	r, _ := getReplacer([]wordrow.Rule{{From: "cat", To: "dog"}}, args)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		watchFiles(args, r, new(wordrow.Report), 10*time.Millisecond, done)
+		close(stopped)
+	}()
+
+	t.Run("Initial update", func(t *testing.T) {
+		waitForContent(t, inputPath, "A dog")
+	})
+	t.Run("Modified file", func(t *testing.T) {
+		writeFile(t, inputPath, "A cat and a dog")
+		waitForContent(t, inputPath, "A dog and a dog")
+	})
+	t.Run("New file", func(t *testing.T) {
+		newPath := filepath.Join(dir, "bar.txt")
+		writeFile(t, newPath, "Another cat")
+		waitForContent(t, newPath, "Another dog")
+	})
+	t.Run("Modified mapping file", func(t *testing.T) {
+		writeFile(t, mapPath, "dog,horse")
+		waitForContent(t, inputPath, "A horse and a horse")
+		waitForContent(t, mapPath, "dog,horse")
+	})
+
+	close(done)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected watching to stop")
+	}
+}
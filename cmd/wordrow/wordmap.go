@@ -1,16 +1,20 @@
 package main
 
 import (
-	"io"
+	"regexp"
 
 	"github.com/ericcornelissen/stringsx"
 	"github.com/ericcornelissen/wordrow/internal/cli"
-	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/errors"
 	"github.com/ericcornelissen/wordrow/internal/fs"
 	"github.com/ericcornelissen/wordrow/internal/logger"
-	"github.com/ericcornelissen/wordrow/internal/mappings"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
+// Regular expression of an explicit DSV format in a --map-file argument, which
+// is matched case insensitively like the format itself.
+var dsvFormatExpr = regexp.MustCompile(`(?i):dsv;`)
+
 // Parse a --map-file argument into its component parts.
 //
 // A --map-file argument can either be just a file path, or a file path with an
@@ -30,9 +34,18 @@ import (
 //
 // In the former the file explicitly stated format, in the latter no format is
 // returned.
+//
+// The separator of an explicit DSV format may itself be a colon. For example:
+//
+//   /path/to/file.txt:dsv;sep=:
 func parseMapFileArgument(argument string) (filePath, format string) {
 	fileExtension := fs.GetExt(argument)
 
+	if matches := dsvFormatExpr.FindAllStringIndex(argument, -1); matches != nil {
+		i := matches[len(matches)-1][0]
+		return argument[:i], argument[i+1:]
+	}
+
 	explicitFormatSplit := stringsx.Split(argument, ":")
 	if len(explicitFormatSplit) > 1 {
 		explicitFormat := explicitFormatSplit[len(explicitFormatSplit)-1]
@@ -43,64 +56,70 @@ func parseMapFileArgument(argument string) (filePath, format string) {
 	return argument, fileExtension
 }
 
-// Add the mapping of the `reader` to the `target`. The `format` argument
-// determines how the contents of the file are parsed. This function returns an
-// error if either the reading or parsing fails.
-func processMapFile(
-	reader io.Reader,
-	format string,
-) (map[string]string, error) {
-	mapping, err := mappings.ParseReader(reader, format)
-	if err != nil {
-		return nil, err
+// Merge the rules `other` into the rules `target`, see wordrow.MergeRules. A
+// debug message is logged for every rule of `target` that is overwritten.
+func mergeRules(target, other []wordrow.Rule) []wordrow.Rule {
+	to := make(map[string]string, len(target))
+	for _, rule := range target {
+		to[rule.From] = rule.To
+	}
+
+	for _, rule := range other {
+		if previous, ok := to[rule.From]; ok {
+			logger.Debugf(
+				"Overwriting '%s': from '%s' to '%s'",
+				rule.From,
+				previous,
+				rule.To,
+			)
+		}
+
+		to[rule.From] = rule.To
 	}
 
-	return mapping, nil
+	return wordrow.MergeRules(target, other)
 }
 
-// Opens the file provided by the handler and add its mapping to the `mapping`.
+// Opens the file provided by the handler and add its mappings to the `mapping`.
 // If the file cannot be opened or processing failed the handler returns an
 // error.
-func openAndProcessMapFileWith(mapping map[string]string) handler {
+func openAndProcessMapFileWith(mapping *[]wordrow.Rule) handler {
 	return func(fileArgument string) error {
 		filePath, format := parseMapFileArgument(fileArgument)
 
-		logger.Debugf("Opening '%s' as a '%s' formatted map file", filePath, format)
-		handle, err := fs.OpenFile(filePath, fs.OReadOnly)
+		logger.Debugf("Processing '%s' as a '%s' formatted map file", filePath, format)
+		newMapping, err := wordrow.ParseFile(filePath, format)
 		if err != nil {
 			return err
 		}
 
-		defer handle.Close()
-
-		logger.Debugf("Processing '%s' as a map file", filePath)
-		newMapping, err := processMapFile(handle, format)
-		if err != nil {
-			return err
-		}
-
-		common.MergeMaps(mapping, newMapping)
+		*mapping = mergeRules(*mapping, newMapping)
 		return nil
 	}
 }
 
 // Processes the value provided by the handler and add its mapping to the
 // `target`. Of the value cannot be parsed as a CSV mapping the handler returns
-// an error.
-func processInlineMapping(value string, target map[string]string) error {
-	mapping, err := mappings.ParseString(&value, "csv")
+// an error. The same goes if the value is parsed as a comment or header row,
+// which define no mapping.
+func processInlineMapping(value string, target *[]wordrow.Rule) error {
+	mapping, err := wordrow.ParseRules(stringsx.NewReader(value), wordrow.FormatCSV)
 	if err != nil {
 		return err
 	}
 
-	common.MergeMaps(target, mapping)
+	if len(mapping) == 0 {
+		return errors.Newf("No mapping in '%s'", value)
+	}
+
+	*target = mergeRules(*target, mapping)
 	return nil
 }
 
 // Processes the value provided by the handler and add its mapping to the
 // `mapping`. Of the value cannot be parsed as a CSV mapping the handler returns
 // an error.
-func processInlineMappingWith(mapping map[string]string) handler {
+func processInlineMappingWith(mapping *[]wordrow.Rule) handler {
 	return func(value string) error {
 		logger.Debugf("Processing '%s' as a CLI specified mapping", value)
 		return processInlineMapping(value, mapping)
@@ -111,17 +130,21 @@ func processInlineMappingWith(mapping map[string]string) handler {
 // that occurs is returned after both have been processed. In case of any error
 // the mapping that is returned represents only the arguments that could be
 // successfully processed.
-func getMapping(args *cli.Arguments) (map[string]string, []error) {
-	mapping := make(map[string]string)
+//
+// The mapping is ordered, first by the order of the `mapFiles`, then by the
+// order of the `inlineMappings`. Within a mapping file the order of the file is
+// maintained.
+func getMapping(args *cli.Arguments) ([]wordrow.Rule, []error) {
+	var mapping []wordrow.Rule
 
-	errs := forEach(args.MapFiles, openAndProcessMapFileWith(mapping))
+	errs := forEach(args.MapFiles, openAndProcessMapFileWith(&mapping))
 	errs = append(
 		errs,
-		forEach(args.Mappings, processInlineMappingWith(mapping))...,
+		forEach(args.Mappings, processInlineMappingWith(&mapping))...,
 	)
 
 	if args.Invert {
-		mapping = invert(mapping)
+		mapping = wordrow.InvertRules(mapping)
 	}
 
 	return mapping, errs
@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/cli"
+	"github.com/ericcornelissen/wordrow/pkg/wordrow"
 )
 
 func TestParseMapFileArgument(t *testing.T) {
@@ -62,16 +63,31 @@ func TestParseMapFileArgument(t *testing.T) {
 			t.Errorf("Unexpected format (got '%s')", format)
 		}
 	})
+	t.Run("File with explicit DSV format", func(t *testing.T) {
+		inputPath := "/hello/world.txt"
+		for _, explicitFormat := range []string{"dsv;sep=|", "dsv;sep=:", "DSV;sep=:"} {
+			input := fmt.Sprintf("%s:%s", inputPath, explicitFormat)
+
+			filePath, format := parseMapFileArgument(input)
+			if filePath != inputPath {
+				t.Errorf("Unexpected filepath (got '%s')", filePath)
+			}
+
+			if format != explicitFormat {
+				t.Errorf("Unexpected format (got '%s')", format)
+			}
+		}
+	})
 }
 
-func TestProcessMapFile(t *testing.T) {
-	t.Run("Read something, correct format", func(t *testing.T) {
-		format := "csv"
-		expectedFrom, expectedTo := "foo", "bar"
-		content := fmt.Sprintf("%s,%s", expectedFrom, expectedTo)
-		handle := stringsx.NewReader(content)
+func TestProcessInlineMapping(t *testing.T) {
+	t.Run("Correct format", func(t *testing.T) {
+		var mapping []wordrow.Rule
 
-		mapping, err := processMapFile(handle, format)
+		expectedFrom, expectedTo := "hello", "hey"
+		value := fmt.Sprintf("%s,%s", expectedFrom, expectedTo)
+
+		err := processInlineMapping(value, &mapping)
 		if err != nil {
 			t.Fatalf("Unexpected error (%s)", err)
 		}
@@ -81,21 +97,19 @@ func TestProcessMapFile(t *testing.T) {
 			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
 		}
 
-		actualTo, ok := mapping[expectedFrom]
-		if !ok {
-			t.Error("From value missing from mapping")
+		if mapping[0].From != expectedFrom {
+			t.Errorf("Incorrect first from value (got '%s')", mapping[0].From)
 		}
 
-		if actualTo != expectedTo {
-			t.Errorf("Incorrect first to value (got '%s')", actualTo)
+		if mapping[0].To != expectedTo {
+			t.Errorf("Incorrect first to value (got '%s')", mapping[0].To)
 		}
 	})
-	t.Run("Read something, incorrect format", func(t *testing.T) {
-		format := "csv"
-		content := "foobar"
-		handle := stringsx.NewReader(content)
+	t.Run("Incorrect format", func(t *testing.T) {
+		var mapping []wordrow.Rule
+		value := "foobar"
 
-		mapping, err := processMapFile(handle, format)
+		err := processInlineMapping(value, &mapping)
 		if err == nil {
 			t.Error("Expected an error but didn't get one")
 		}
@@ -105,14 +119,13 @@ func TestProcessMapFile(t *testing.T) {
 			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
 		}
 	})
-	t.Run("Read nothing", func(t *testing.T) {
-		format := "csv"
-		content := ""
-		handle := stringsx.NewReader(content)
+	t.Run("No mapping", func(t *testing.T) {
+		var mapping []wordrow.Rule
 
-		mapping, err := processMapFile(handle, format)
-		if err != nil {
-			t.Fatalf("Unexpected error (%s)", err)
+		for _, value := range []string{"#dog,cat", "from,to", " "} {
+			if err := processInlineMapping(value, &mapping); err == nil {
+				t.Errorf("Expected an error for '%s' but got none", value)
+			}
 		}
 
 		mappingSize := len(mapping)
@@ -120,62 +133,85 @@ func TestProcessMapFile(t *testing.T) {
 			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
 		}
 	})
-}
-
-func TestProcessInlineMapping(t *testing.T) {
-	t.Run("Correct format", func(t *testing.T) {
-		mapping := make(map[string]string, 1)
+	t.Run("Empty string", func(t *testing.T) {
+		var mapping []wordrow.Rule
 
-		expectedFrom, expectedTo := "hello", "hey"
-		value := fmt.Sprintf("%s,%s", expectedFrom, expectedTo)
+		if err := processInlineMapping("foo,", &mapping); err == nil {
+			t.Errorf("Expected no error but got one (%s)", err)
+		}
 
-		err := processInlineMapping(value, mapping)
-		if err != nil {
-			t.Fatalf("Unexpected error (%s)", err)
+		if err := processInlineMapping(",bar", &mapping); err == nil {
+			t.Errorf("Expected no error but got one (%s)", err)
 		}
 
 		mappingSize := len(mapping)
-		if mappingSize != 1 {
+		if mappingSize != 0 {
 			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
 		}
+	})
+}
+
+func TestGetMapping(t *testing.T) {
+	t.Run("Maintains order", func(t *testing.T) {
+		args := cli.Arguments{
+			Mappings: []string{"b,1", "a,2", "c,3"},
+		}
+
+		mapping, errs := getMapping(&args)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (%s)", errs)
+		}
 
-		actualTo, ok := mapping[expectedFrom]
-		if !ok {
-			t.Error("From value missing from mapping")
+		if len(mapping) != 3 {
+			t.Fatalf("Unexpected mapping size (got %d)", len(mapping))
 		}
 
-		if actualTo != expectedTo {
-			t.Errorf("Incorrect first to value (got '%s')", actualTo)
+		for i, expectedFrom := range []string{"b", "a", "c"} {
+			if mapping[i].From != expectedFrom {
+				t.Errorf("Unexpected from value at %d (got '%s')", i, mapping[i].From)
+			}
 		}
 	})
-	t.Run("Incorrect format", func(t *testing.T) {
-		mapping := make(map[string]string, 1)
-		value := "foobar"
+	t.Run("Inverted", func(t *testing.T) {
+		args := cli.Arguments{
+			Invert:   true,
+			Mappings: []string{"foo,bar"},
+		}
 
-		err := processInlineMapping(value, mapping)
-		if err == nil {
-			t.Error("Expected an error but didn't get one")
+		mapping, errs := getMapping(&args)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (%s)", errs)
 		}
 
-		mappingSize := len(mapping)
-		if mappingSize != 0 {
-			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
+		if len(mapping) != 1 {
+			t.Fatalf("Unexpected mapping size (got %d)", len(mapping))
+		}
+
+		if mapping[0].From != "bar" || mapping[0].To != "foo" {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", mapping[0].From, mapping[0].To)
 		}
 	})
-	t.Run("Empty string", func(t *testing.T) {
-		mapping := make(map[string]string, 1)
+	t.Run("Map file source", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "map.csv", "foo,bar\n\nhello,world\n")
+		defer cleanup()
 
-		if err := processInlineMapping("foo,", mapping); err == nil {
-			t.Errorf("Expected no error but got one (%s)", err)
+		args := cli.Arguments{
+			MapFiles: []string{path},
 		}
 
-		if err := processInlineMapping(",bar", mapping); err == nil {
-			t.Errorf("Expected no error but got one (%s)", err)
+		mapping, errs := getMapping(&args)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (%s)", errs)
 		}
 
-		mappingSize := len(mapping)
-		if mappingSize != 0 {
-			t.Fatalf("Unexpected mapping size (got %d)", mappingSize)
+		if len(mapping) != 2 {
+			t.Fatalf("Unexpected mapping size (got %d)", len(mapping))
+		}
+
+		for i, expectedLine := range []int{1, 3} {
+			if mapping[i].Source != path || mapping[i].Line != expectedLine {
+				t.Errorf("Unexpected source at %d (got '%s' line %d)", i, mapping[i].Source, mapping[i].Line)
+			}
 		}
 	})
 }
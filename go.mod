@@ -4,5 +4,9 @@ go 1.13
 
 require (
 	github.com/ericcornelissen/stringsx v0.0.0-20201216175831-0d06dc74ad0e
+	github.com/pelletier/go-toml v1.9.5
+	github.com/xeipuuv/gojsonschema v1.2.0
 	github.com/yargevad/filepathx v1.0.0
+	golang.org/x/text v0.3.5
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -1,6 +1,11 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ericcornelissen/wordrow/internal/errors"
+)
 
 // A custom integer type for an Enum to keep track of the arguments context.
 type argContext int
@@ -15,10 +20,43 @@ const (
 
 	// The context where arguments are interpreted as a mapping.
 	contextMapping
+
+	// The context where arguments are interpreted as the number of context lines
+	// of a diff.
+	contextDiffContext
+
+	// The context where arguments are interpreted as the name of STDIN.
+	contextStdinName
+
+	// The context where arguments are interpreted as the format of a report.
+	contextReport
+
+	// The context where arguments are interpreted as the number of jobs.
+	contextJobs
+
+	// The context where arguments are interpreted as globs to include.
+	contextInclude
+
+	// The context where arguments are interpreted as globs to exclude.
+	contextExclude
+
+	// The context where arguments are interpreted as file extensions.
+	contextExtension
+
+	// The context where arguments are interpreted as the maximum depth to walk
+	// directories to.
+	contextMaxDepth
+
+	// The context where arguments are interpreted as the policy for symbolic
+	// links.
+	contextSymlinks
 )
 
 // Parse an argument that is not in option within a certain argument context.
-func (context argContext) parseValue(value string, arguments *Arguments) {
+//
+// The function returns an error if the argument is not a valid value in the
+// context.
+func (context argContext) parseValue(value string, arguments *Arguments) error {
 	switch context {
 	case contextDefault:
 		arguments.InputFiles = append(arguments.InputFiles, value)
@@ -26,7 +64,50 @@ func (context argContext) parseValue(value string, arguments *Arguments) {
 		arguments.MapFiles = append(arguments.MapFiles, value)
 	case contextMapping:
 		arguments.Mappings = append(arguments.Mappings, value)
+	case contextDiffContext:
+		lines, err := strconv.Atoi(value)
+		if err != nil || lines < 0 {
+			return errors.Newf("Invalid number of lines '%s' for %s", value, context)
+		}
+
+		arguments.DiffContext = lines
+	case contextStdinName:
+		arguments.StdinName = value
+	case contextReport:
+		if !reportFormats[value] {
+			return errors.Newf("Unknown report format '%s' for %s", value, context)
+		}
+
+		arguments.Report = value
+	case contextJobs:
+		jobs, err := strconv.Atoi(value)
+		if err != nil || jobs < 1 {
+			return errors.Newf("Invalid number of jobs '%s' for %s", value, context)
+		}
+
+		arguments.Jobs = jobs
+	case contextInclude:
+		arguments.Includes = append(arguments.Includes, value)
+	case contextExclude:
+		arguments.Excludes = append(arguments.Excludes, value)
+	case contextExtension:
+		arguments.Extensions = append(arguments.Extensions, value)
+	case contextMaxDepth:
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			return errors.Newf("Invalid depth '%s' for %s", value, context)
+		}
+
+		arguments.MaxDepth = depth
+	case contextSymlinks:
+		if !symlinkPolicies[value] {
+			return errors.Newf("Unknown symbolic link policy '%s' for %s", value, context)
+		}
+
+		arguments.Symlinks = value
 	}
+
+	return nil
 }
 
 // Get an argContext as a human readable string.
@@ -36,6 +117,15 @@ func (context argContext) String() string {
 		"Unknown",
 		fmt.Sprintf(template, mapfileOption.name, mapfileOption.alias),
 		fmt.Sprintf(template, mappingOption.name, mappingOption.alias),
+		diffContextOption.name,
+		stdinNameOption.name,
+		reportOption.name,
+		fmt.Sprintf(template, jobsOption.name, jobsOption.alias),
+		includeOption.name,
+		excludeOption.name,
+		extensionOption.name,
+		maxDepthOption.name,
+		symlinksOption.name,
 	}
 
 	return names[context]
@@ -21,4 +21,58 @@ func TestArgContextToString(t *testing.T) {
 			t.Error("result should not be an empty string")
 		}
 	})
+	t.Run("contextDiffContext", func(t *testing.T) {
+		result := contextDiffContext.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextStdinName", func(t *testing.T) {
+		result := contextStdinName.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextReport", func(t *testing.T) {
+		result := contextReport.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextJobs", func(t *testing.T) {
+		result := contextJobs.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextInclude", func(t *testing.T) {
+		result := contextInclude.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextExclude", func(t *testing.T) {
+		result := contextExclude.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextExtension", func(t *testing.T) {
+		result := contextExtension.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextMaxDepth", func(t *testing.T) {
+		result := contextMaxDepth.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
+	t.Run("contextSymlinks", func(t *testing.T) {
+		result := contextSymlinks.String()
+		if result == "" {
+			t.Error("result should not be an empty string")
+		}
+	})
 }
@@ -1,5 +1,48 @@
 package cli
 
+// The default number of context lines of a diff.
+const defaultDiffContext = 3
+
+// The default name of STDIN in the output.
+const defaultStdinName = "stdin"
+
+// The names of the formats for a report.
+const (
+	// ReportJSON is the name of the JSON format for a report.
+	ReportJSON = "json"
+
+	// ReportSARIF is the name of the SARIF format for a report.
+	ReportSARIF = "sarif"
+)
+
+// The default policy for symbolic links in input directories.
+const defaultSymlinks = SymlinksSkip
+
+// The policies for symbolic links in input directories.
+const (
+	// SymlinksFollow is the name of the policy to follow symbolic links.
+	SymlinksFollow = "follow"
+
+	// SymlinksSkip is the name of the policy to leave out symbolic links.
+	SymlinksSkip = "skip"
+
+	// SymlinksError is the name of the policy to report symbolic links as errors.
+	SymlinksError = "error"
+)
+
+// The policies available for symbolic links in input directories.
+var symlinkPolicies = map[string]bool{
+	SymlinksFollow: true,
+	SymlinksSkip:   true,
+	SymlinksError:  true,
+}
+
+// The formats available for a report.
+var reportFormats = map[string]bool{
+	ReportJSON:  true,
+	ReportSARIF: true,
+}
+
 // The Arguments type represents the configuration of the program from the
 // Command-Line Interface (CLI).
 type Arguments struct {
@@ -12,9 +55,36 @@ type Arguments struct {
 	// Flag indicating if this is a dry run.
 	DryRun bool
 
+	// Flag indicating if the program should only report changes.
+	Check bool
+
+	// Flag indicating if the program should output changes as a diff.
+	Diff bool
+
+	// Flag indicating if every replacement should be reviewed interactively.
+	Interactive bool
+
+	// Flag indicating if the input files should be watched for changes.
+	Watch bool
+
+	// Flag indicating if hidden and "node_modules" directories should be walked.
+	NoDefaultExcludes bool
+
+	// Flag indicating if ignore files should be disregarded.
+	NoIgnore bool
+
+	// Flag indicating if symbolic links in input paths should be resolved.
+	ResolveSymlinks bool
+
+	// Flag indicating if written files should be synced to the storage device.
+	Fsync bool
+
 	// Flag indicating if the mapping should be inverted.
 	Invert bool
 
+	// Flag indicating if the mappings should be applied simultaneously.
+	Simultaneous bool
+
 	// Flag indicating if the program should be silent.
 	Silent bool
 
@@ -32,4 +102,31 @@ type Arguments struct {
 
 	// List of mappings defined in the CLI.
 	Mappings []string
+
+	// The number of context lines of a diff.
+	DiffContext int
+
+	// The name of STDIN in the output.
+	StdinName string
+
+	// The format of the report to output, if any.
+	Report string
+
+	// The number of input files to process in parallel, 0 for the default.
+	Jobs int
+
+	// List of globs of which input files must match at least one, if any.
+	Includes []string
+
+	// List of globs of input files to leave out.
+	Excludes []string
+
+	// List of extensions of which input files must have one, if any.
+	Extensions []string
+
+	// The maximum depth to walk input directories to, 0 if there is no limit.
+	MaxDepth int
+
+	// The policy for symbolic links in input directories.
+	Symlinks string
 }
@@ -17,6 +17,78 @@ func testDefaultDryRun(t *testing.T, arguments *Arguments) {
 	}
 }
 
+// Test if Check has the default value.
+func testDefaultCheck(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Check == true {
+		t.Error("The default value for the Check option should be false")
+	}
+}
+
+// Test if Diff has the default value.
+func testDefaultDiff(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Diff == true {
+		t.Error("The default value for the Diff option should be false")
+	}
+}
+
+// Test if Interactive has the default value.
+func testDefaultInteractive(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Interactive == true {
+		t.Error("The default value for the Interactive option should be false")
+	}
+}
+
+// Test if Watch has the default value.
+func testDefaultWatch(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Watch == true {
+		t.Error("The default value for the Watch option should be false")
+	}
+}
+
+// Test if NoDefaultExcludes has the default value.
+func testDefaultNoDefaultExcludes(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.NoDefaultExcludes == true {
+		t.Error("The default value for the NoDefaultExcludes option should be false")
+	}
+}
+
+// Test if NoIgnore has the default value.
+func testDefaultNoIgnore(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.NoIgnore == true {
+		t.Error("The default value for the NoIgnore option should be false")
+	}
+}
+
+// Test if ResolveSymlinks has the default value.
+func testDefaultResolveSymlinks(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.ResolveSymlinks == true {
+		t.Error("The default value for the ResolveSymlinks option should be false")
+	}
+}
+
+// Test if Fsync has the default value.
+func testDefaultFsync(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Fsync == true {
+		t.Error("The default value for the Fsync option should be false")
+	}
+}
+
 // Test if Invert has the default value.
 func testDefaultInvert(t *testing.T, arguments *Arguments) {
 	t.Helper()
@@ -26,6 +98,15 @@ func testDefaultInvert(t *testing.T, arguments *Arguments) {
 	}
 }
 
+// Test if Simultaneous has the default value.
+func testDefaultSimultaneous(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Simultaneous == true {
+		t.Error("The default value for the Simultaneous option should be false")
+	}
+}
+
 // Test if Silent has the default value.
 func testDefaultSilent(t *testing.T, arguments *Arguments) {
 	t.Helper()
@@ -71,6 +152,87 @@ func testDefaultMappings(t *testing.T, arguments *Arguments) {
 	}
 }
 
+// Test if DiffContext has the default value.
+func testDefaultDiffContext(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.DiffContext != defaultDiffContext {
+		t.Errorf("The default value for the DiffContext option should be %d", defaultDiffContext)
+	}
+}
+
+// Test if StdinName has the default value.
+func testDefaultStdinName(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.StdinName != defaultStdinName {
+		t.Errorf("The default value for the StdinName option should be '%s'", defaultStdinName)
+	}
+}
+
+// Test if Report has the default value.
+func testDefaultReport(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Report != "" {
+		t.Error("The default value for the Report option should be empty")
+	}
+}
+
+// Test if Jobs has the default value.
+func testDefaultJobs(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Jobs != 0 {
+		t.Error("The default value for the Jobs option should be 0")
+	}
+}
+
+// Test if Includes has the default value.
+func testDefaultIncludes(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if len(arguments.Includes) != 0 {
+		t.Error("The default list of Includes should be empty")
+	}
+}
+
+// Test if Excludes has the default value.
+func testDefaultExcludes(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if len(arguments.Excludes) != 0 {
+		t.Error("The default list of Excludes should be empty")
+	}
+}
+
+// Test if Extensions has the default value.
+func testDefaultExtensions(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if len(arguments.Extensions) != 0 {
+		t.Error("The default list of Extensions should be empty")
+	}
+}
+
+// Test if MaxDepth has the default value.
+func testDefaultMaxDepth(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.MaxDepth != 0 {
+		t.Error("The default value for the MaxDepth option should be 0")
+	}
+}
+
+// Test if Symlinks has the default value.
+func testDefaultSymlinks(t *testing.T, arguments *Arguments) {
+	t.Helper()
+
+	if arguments.Symlinks != defaultSymlinks {
+		t.Errorf("The default value for the Symlinks option should be '%s'", defaultSymlinks)
+	}
+}
+
 // Test if all default values of an Arguments instance except one.
 func testDefaultsExcept(t *testing.T, arguments *Arguments, exclude string) {
 	t.Helper()
@@ -78,9 +240,36 @@ func testDefaultsExcept(t *testing.T, arguments *Arguments, exclude string) {
 	if exclude != "dryrun" {
 		testDefaultDryRun(t, arguments)
 	}
+	if exclude != "check" {
+		testDefaultCheck(t, arguments)
+	}
+	if exclude != "diff" {
+		testDefaultDiff(t, arguments)
+	}
+	if exclude != "interactive" {
+		testDefaultInteractive(t, arguments)
+	}
+	if exclude != "watch" {
+		testDefaultWatch(t, arguments)
+	}
+	if exclude != "no default excludes" {
+		testDefaultNoDefaultExcludes(t, arguments)
+	}
+	if exclude != "no ignore" {
+		testDefaultNoIgnore(t, arguments)
+	}
+	if exclude != "resolve symlinks" {
+		testDefaultResolveSymlinks(t, arguments)
+	}
+	if exclude != "fsync" {
+		testDefaultFsync(t, arguments)
+	}
 	if exclude != "invert" {
 		testDefaultInvert(t, arguments)
 	}
+	if exclude != "simultaneous" {
+		testDefaultSimultaneous(t, arguments)
+	}
 	if exclude != "silent" {
 		testDefaultSilent(t, arguments)
 	}
@@ -96,4 +285,31 @@ func testDefaultsExcept(t *testing.T, arguments *Arguments, exclude string) {
 	if exclude != "mappings" {
 		testDefaultMappings(t, arguments)
 	}
+	if exclude != "diff context" {
+		testDefaultDiffContext(t, arguments)
+	}
+	if exclude != "stdin name" {
+		testDefaultStdinName(t, arguments)
+	}
+	if exclude != "report" {
+		testDefaultReport(t, arguments)
+	}
+	if exclude != "jobs" {
+		testDefaultJobs(t, arguments)
+	}
+	if exclude != "includes" {
+		testDefaultIncludes(t, arguments)
+	}
+	if exclude != "excludes" {
+		testDefaultExcludes(t, arguments)
+	}
+	if exclude != "extensions" {
+		testDefaultExtensions(t, arguments)
+	}
+	if exclude != "max depth" {
+		testDefaultMaxDepth(t, arguments)
+	}
+	if exclude != "symlinks" {
+		testDefaultSymlinks(t, arguments)
+	}
 }
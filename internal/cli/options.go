@@ -26,6 +26,54 @@ var (
 		name: "--dry-run",
 	}
 
+	// The flag to enable check mode. If enabled the program won't make any
+	// changes to the input files, but reports the changes it would make.
+	checkFlag = option{
+		name: "--check",
+	}
+
+	// The flag to output a diff. If enabled the program won't make any changes
+	// to the input files, but outputs the changes it would make as a diff.
+	diffFlag = option{
+		name: "--diff",
+	}
+
+	// The flag to enable interactive mode. If enabled the program asks whether
+	// to make every replacement before making it.
+	interactiveFlag = option{
+		name: "--interactive",
+	}
+
+	// The flag to enable watch mode. If enabled the program keeps running and
+	// updates the input files again whenever they, or the mapping files, change.
+	watchFlag = option{
+		name: "--watch",
+	}
+
+	// The flag to walk all directories. If enabled hidden directories and
+	// "node_modules" directories are walked as well.
+	noDefaultExcludesFlag = option{
+		name: "--no-default-excludes",
+	}
+
+	// The flag to disregard ignore files. If enabled .gitignore and .wordrowignore
+	// files are not used to leave out files when walking directories.
+	noIgnoreFlag = option{
+		name: "--no-ignore",
+	}
+
+	// The flag to resolve symbolic links in input paths. If enabled input files
+	// reachable through different symbolic links are only processed once.
+	resolveSymlinksFlag = option{
+		name: "--resolve-symlinks",
+	}
+
+	// The flag to sync written files. If enabled every updated file is synced to
+	// the storage device before the program continues.
+	fsyncFlag = option{
+		name: "--fsync",
+	}
+
 	// The flag to invert the mapping. If enabled the mapping will be used right-
 	// to-left instead of left-to-right.
 	invertFlag = option{
@@ -33,6 +81,13 @@ var (
 		alias: "-i",
 	}
 
+	// The flag to apply all mappings simultaneously. If enabled the mappings are
+	// matched against the original text and the output of one mapping won't be
+	// affected by other mappings.
+	simultaneousFlag = option{
+		name: "--simultaneous",
+	}
+
 	// The flag to make the program silent.
 	silentFlag = option{
 		name:  "--silent",
@@ -62,4 +117,50 @@ var (
 		name:  "--map",
 		alias: "-m",
 	}
+
+	// The option to specify the number of context lines of a diff.
+	diffContextOption = option{
+		name: "--diff-context",
+	}
+
+	// The option to specify the name of STDIN in the output.
+	stdinNameOption = option{
+		name: "--stdin-name",
+	}
+
+	// The option to specify the format of a report to output.
+	reportOption = option{
+		name: "--report",
+	}
+
+	// The option to specify the number of input files processed in parallel.
+	jobsOption = option{
+		name:  "--jobs",
+		alias: "-j",
+	}
+
+	// The option to specify a glob that input files must match.
+	includeOption = option{
+		name: "--include",
+	}
+
+	// The option to specify a glob of input files to leave out.
+	excludeOption = option{
+		name: "--exclude",
+	}
+
+	// The option to specify an extension that input files must have.
+	extensionOption = option{
+		name: "--ext",
+	}
+
+	// The option to specify the maximum depth to walk input directories to.
+	maxDepthOption = option{
+		name: "--max-depth",
+	}
+
+	// The option to specify what to do with symbolic links in input directories.
+	symlinksOption = option{
+		name: "--symlinks",
+	}
 )
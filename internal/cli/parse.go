@@ -53,8 +53,26 @@ func parseArgumentAsOption(
 	// Flags
 	case dryRunFlag.name:
 		arguments.DryRun = true
+	case checkFlag.name:
+		arguments.Check = true
+	case diffFlag.name:
+		arguments.Diff = true
+	case interactiveFlag.name:
+		arguments.Interactive = true
+	case watchFlag.name:
+		arguments.Watch = true
+	case noDefaultExcludesFlag.name:
+		arguments.NoDefaultExcludes = true
+	case noIgnoreFlag.name:
+		arguments.NoIgnore = true
+	case resolveSymlinksFlag.name:
+		arguments.ResolveSymlinks = true
+	case fsyncFlag.name:
+		arguments.Fsync = true
 	case invertFlag.name, invertFlag.alias:
 		arguments.Invert = true
+	case simultaneousFlag.name:
+		arguments.Simultaneous = true
 	case silentFlag.name, silentFlag.alias:
 		arguments.Silent = true
 	case verboseFlag.name, verboseFlag.alias:
@@ -67,6 +85,24 @@ func parseArgumentAsOption(
 		newContext = contextMapFile
 	case mappingOption.name, mappingOption.alias:
 		newContext = contextMapping
+	case diffContextOption.name:
+		newContext = contextDiffContext
+	case stdinNameOption.name:
+		newContext = contextStdinName
+	case reportOption.name:
+		newContext = contextReport
+	case jobsOption.name, jobsOption.alias:
+		newContext = contextJobs
+	case includeOption.name:
+		newContext = contextInclude
+	case excludeOption.name:
+		newContext = contextExclude
+	case extensionOption.name:
+		newContext = contextExtension
+	case maxDepthOption.name:
+		newContext = contextMaxDepth
+	case symlinksOption.name:
+		newContext = contextSymlinks
 	default:
 		return newContext, errors.Newf("Unknown option '%s'. Use %s for help", option, helpFlag)
 	}
@@ -109,7 +145,7 @@ func doParseOneArgument(
 	if context == contextDefault && stringsx.HasPrefix(arg, "-") {
 		newContext, err = doParseOneOption(arg, arguments)
 	} else {
-		context.parseValue(arg, arguments)
+		err = context.parseValue(arg, arguments)
 		newContext = contextDefault
 	}
 
@@ -138,9 +174,18 @@ func doParseProgramArguments(args []string, arguments *Arguments) error {
 	return nil
 }
 
+// Set the default values of the `arguments` that are not the zero value.
+func setDefaults(arguments *Arguments) {
+	arguments.DiffContext = defaultDiffContext
+	arguments.StdinName = defaultStdinName
+	arguments.Symlinks = defaultSymlinks
+}
+
 // ParseArgs parses a list of arguments (e.g. `os.Args`) into an Arguments
 // instance.
 func ParseArgs(args []string) (run bool, arguments Arguments) {
+	setDefaults(&arguments)
+
 	err := doParseProgramArguments(args[1:], &arguments)
 	if err != nil {
 		logger.Fatalf("An error occurred while parsing arguments: %s", err)
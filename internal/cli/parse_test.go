@@ -115,6 +115,141 @@ func TestInvertFlag(t *testing.T) {
 	})
 }
 
+func TestCheckFlag(t *testing.T) {
+	args := createArgs(checkFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "check")
+
+	if arguments.Check != true {
+		t.Errorf("The Check value should be true if %s is an argument", checkFlag)
+	}
+}
+
+func TestDiffFlag(t *testing.T) {
+	args := createArgs(diffFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "diff")
+
+	if arguments.Diff != true {
+		t.Errorf("The Diff value should be true if %s is an argument", diffFlag)
+	}
+}
+
+func TestInteractiveFlag(t *testing.T) {
+	args := createArgs(interactiveFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "interactive")
+
+	if arguments.Interactive != true {
+		t.Errorf("The Interactive value should be true if %s is an argument", interactiveFlag)
+	}
+}
+
+func TestWatchFlag(t *testing.T) {
+	args := createArgs(watchFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "watch")
+
+	if arguments.Watch != true {
+		t.Errorf("The Watch value should be true if %s is an argument", watchFlag)
+	}
+}
+
+func TestNoDefaultExcludesFlag(t *testing.T) {
+	args := createArgs(noDefaultExcludesFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "no default excludes")
+
+	if arguments.NoDefaultExcludes != true {
+		t.Errorf("The NoDefaultExcludes value should be true if %s is an argument", noDefaultExcludesFlag)
+	}
+}
+
+func TestNoIgnoreFlag(t *testing.T) {
+	args := createArgs(noIgnoreFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "no ignore")
+
+	if arguments.NoIgnore != true {
+		t.Errorf("The NoIgnore value should be true if %s is an argument", noIgnoreFlag)
+	}
+}
+
+func TestResolveSymlinksFlag(t *testing.T) {
+	args := createArgs(resolveSymlinksFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "resolve symlinks")
+
+	if arguments.ResolveSymlinks != true {
+		t.Errorf("The ResolveSymlinks value should be true if %s is an argument", resolveSymlinksFlag)
+	}
+}
+
+func TestFsyncFlag(t *testing.T) {
+	args := createArgs(fsyncFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "fsync")
+
+	if arguments.Fsync != true {
+		t.Errorf("The Fsync value should be true if %s is an argument", fsyncFlag)
+	}
+}
+
+func TestSimultaneousFlag(t *testing.T) {
+	args := createArgs(simultaneousFlag.name, "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "simultaneous")
+
+	if arguments.Simultaneous != true {
+		t.Errorf("The Simultaneous value should be true if %s is an argument", simultaneousFlag)
+	}
+}
+
 func TestSilentFlag(t *testing.T) {
 	t.Run(silentFlag.name, func(t *testing.T) {
 		args := createArgs(silentFlag.name, "foo.bar")
@@ -486,6 +621,254 @@ func TestMappingOptionIncorrect(t *testing.T) {
 	})
 }
 
+func TestDiffContextOption(t *testing.T) {
+	t.Run("some lines", func(t *testing.T) {
+		args := createArgs(diffContextOption.name, "5", "foo.bar")
+		run, arguments := ParseArgs(args)
+
+		if run != true {
+			t.Fatal("The first return value should be true for this test")
+		}
+
+		testDefaultsExcept(t, &arguments, "diff context")
+
+		if arguments.DiffContext != 5 {
+			t.Errorf("The DiffContext value was incorrect (was %d)", arguments.DiffContext)
+		}
+	})
+	t.Run("no lines", func(t *testing.T) {
+		args := createArgs(diffContextOption.name, "0", "foo.bar")
+		run, arguments := ParseArgs(args)
+
+		if run != true {
+			t.Fatal("The first return value should be true for this test")
+		}
+
+		if arguments.DiffContext != 0 {
+			t.Errorf("The DiffContext value was incorrect (was %d)", arguments.DiffContext)
+		}
+	})
+}
+
+func TestDiffContextOptionIncorrect(t *testing.T) {
+	t.Run("value missing", func(t *testing.T) {
+		args := createArgs(diffContextOption.name)
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+	t.Run("not a number", func(t *testing.T) {
+		args := createArgs(diffContextOption.name, "foo", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+	t.Run("negative number", func(t *testing.T) {
+		args := createArgs(diffContextOption.name, "-1", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+}
+
+func TestStdinNameOption(t *testing.T) {
+	args := createArgs(stdinNameOption.name, "foo.txt", "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "stdin name")
+
+	if arguments.StdinName != "foo.txt" {
+		t.Errorf("The StdinName value was incorrect (was '%s')", arguments.StdinName)
+	}
+}
+
+func TestReportOption(t *testing.T) {
+	for _, format := range []string{ReportJSON, ReportSARIF} {
+		args := createArgs(reportOption.name, format, "foo.bar")
+		run, arguments := ParseArgs(args)
+
+		if run != true {
+			t.Fatal("The first return value should be true for this test")
+		}
+
+		testDefaultsExcept(t, &arguments, "report")
+
+		if arguments.Report != format {
+			t.Errorf("The Report value was incorrect (was '%s')", arguments.Report)
+		}
+	}
+}
+
+func TestReportOptionIncorrect(t *testing.T) {
+	t.Run("value missing", func(t *testing.T) {
+		args := createArgs(reportOption.name)
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+	t.Run("unknown format", func(t *testing.T) {
+		args := createArgs(reportOption.name, "xml", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+}
+
+func TestJobsOption(t *testing.T) {
+	for _, option := range []string{jobsOption.name, jobsOption.alias} {
+		args := createArgs(option, "4", "foo.bar")
+		run, arguments := ParseArgs(args)
+
+		if run != true {
+			t.Fatal("The first return value should be true for this test")
+		}
+
+		testDefaultsExcept(t, &arguments, "jobs")
+
+		if arguments.Jobs != 4 {
+			t.Errorf("The Jobs value was incorrect (was %d)", arguments.Jobs)
+		}
+	}
+}
+
+func TestJobsOptionIncorrect(t *testing.T) {
+	t.Run("not a number", func(t *testing.T) {
+		args := createArgs(jobsOption.name, "foo", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+	t.Run("zero", func(t *testing.T) {
+		args := createArgs(jobsOption.name, "0", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+}
+
+func TestIncludeOption(t *testing.T) {
+	args := createArgs(includeOption.name, "*.md", includeOption.name, "*.txt", "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "includes")
+
+	if len(arguments.Includes) != 2 || arguments.Includes[1] != "*.txt" {
+		t.Errorf("The Includes value was incorrect (was %q)", arguments.Includes)
+	}
+}
+
+func TestExcludeOption(t *testing.T) {
+	args := createArgs(excludeOption.name, "vendor", "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "excludes")
+
+	if len(arguments.Excludes) != 1 || arguments.Excludes[0] != "vendor" {
+		t.Errorf("The Excludes value was incorrect (was %q)", arguments.Excludes)
+	}
+}
+
+func TestExtensionOption(t *testing.T) {
+	args := createArgs(extensionOption.name, "md", "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "extensions")
+
+	if len(arguments.Extensions) != 1 || arguments.Extensions[0] != "md" {
+		t.Errorf("The Extensions value was incorrect (was %q)", arguments.Extensions)
+	}
+}
+
+func TestMaxDepthOption(t *testing.T) {
+	args := createArgs(maxDepthOption.name, "2", "foo.bar")
+	run, arguments := ParseArgs(args)
+
+	if run != true {
+		t.Fatal("The first return value should be true for this test")
+	}
+
+	testDefaultsExcept(t, &arguments, "max depth")
+
+	if arguments.MaxDepth != 2 {
+		t.Errorf("The MaxDepth value was incorrect (was %d)", arguments.MaxDepth)
+	}
+}
+
+func TestMaxDepthOptionIncorrect(t *testing.T) {
+	t.Run("not a number", func(t *testing.T) {
+		args := createArgs(maxDepthOption.name, "foo", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+	t.Run("negative number", func(t *testing.T) {
+		args := createArgs(maxDepthOption.name, "-1", "foo.bar")
+		run, _ := ParseArgs(args)
+
+		if run != false {
+			t.Error("The first return value should be false if there is an error in the args")
+		}
+	})
+}
+
+func TestSymlinksOption(t *testing.T) {
+	for _, policy := range []string{SymlinksFollow, SymlinksError} {
+		args := createArgs(symlinksOption.name, policy, "foo.bar")
+		run, arguments := ParseArgs(args)
+
+		if run != true {
+			t.Fatal("The first return value should be true for this test")
+		}
+
+		testDefaultsExcept(t, &arguments, "symlinks")
+
+		if arguments.Symlinks != policy {
+			t.Errorf("The Symlinks value was incorrect (was '%s')", arguments.Symlinks)
+		}
+	}
+}
+
+func TestSymlinksOptionIncorrect(t *testing.T) {
+	args := createArgs(symlinksOption.name, "ignore", "foo.bar")
+	run, _ := ParseArgs(args)
+
+	if run != false {
+		t.Error("The first return value should be false if there is an error in the args")
+	}
+}
+
 func TestArgumentWithEquals(t *testing.T) {
 	t.Run("Valid option", func(t *testing.T) {
 		args := createArgs("--map=foo,bar")
@@ -74,7 +74,40 @@ func printOptions() {
 	printOption(helpFlag, `Output this help message.`)
 	printOption(versionFlag, `Output the version number of the program.`)
 	printOption(dryRunFlag, `Don't make any changes to the input files.`)
+	printOption(checkFlag, `
+		Don't make any changes to the input files, instead report the changes that
+		would be made and exit with a non-zero exit code if there are any.
+	`)
+	printOption(diffFlag, `
+		Don't make any changes to the input files, instead output the changes that
+		would be made as a unified diff.
+	`)
+	printOption(interactiveFlag, `
+		Ask whether to make every replacement before making it.
+	`)
+	printOption(watchFlag, `
+		Keep running and update input files again whenever they, or the mapping
+		files, change.
+	`)
+	printOption(noDefaultExcludesFlag, `
+		Also walk hidden directories, such as .git, and node_modules directories
+		when walking input directories.
+	`)
+	printOption(noIgnoreFlag, `
+		Don't leave out files and directories matched by .gitignore and
+		.wordrowignore files when walking input directories.
+	`)
+	printOption(resolveSymlinksFlag, `
+		Resolve symbolic links in the paths of input files, so that files reachable
+		through different links are processed only once.
+	`)
+	printOption(fsyncFlag, `
+		Sync every updated file to the storage device before continuing.
+	`)
 	printOption(invertFlag, `Invert all specified mappings.`)
+	printOption(simultaneousFlag, `
+		Apply all mappings simultaneously instead of one after the other.
+	`)
 	printOption(silentFlag, `Disable informative logging.`)
 	printOption(verboseFlag, `Enable debug logging.`)
 	printOption(strictFlag, `Enable strict mode.`)
@@ -89,6 +122,41 @@ func printOptions() {
 		spaces are required use quotation marks. This option can be used multiple
 		times.
 	`)
+	printOption(diffContextOption, `
+		Specify the number of lines of context in a diff. Defaults to 3.
+	`)
+	printOption(stdinNameOption, `
+		Specify the name of STDIN in the output of the --check and --diff flags.
+		Defaults to "stdin".
+	`)
+	printOption(reportOption, `
+		Output a report of all replacements in the specified format instead of the
+		regular output. The available formats are "json" and "sarif".
+	`)
+	printOption(jobsOption, `
+		Specify the number of input files to process in parallel. Defaults to the
+		number of CPUs.
+	`)
+	printOption(includeOption, `
+		Only process input files matching the specified glob. This option can be
+		used multiple times.
+	`)
+	printOption(excludeOption, `
+		Don't process input files or walk directories matching the specified glob.
+		This option can be used multiple times.
+	`)
+	printOption(extensionOption, `
+		Only process input files with the specified extension. This option can be
+		used multiple times.
+	`)
+	printOption(maxDepthOption, `
+		Specify the maximum depth to walk input directories to. Defaults to 0, for
+		no limit.
+	`)
+	printOption(symlinksOption, `
+		Specify what to do with symbolic links in input directories, one of
+		"follow", "skip", or "error". Defaults to "skip".
+	`)
 }
 
 // Print the usage of the CLI of the program.
@@ -101,16 +169,26 @@ func printInterface() {
 		helpFlag.name,
 		versionFlag.name,
 	)
-	fmt.Printf("%s [%s] [%s | %s]\n",
+	fmt.Printf("%s [%s] [%s] [%s | %s]\n",
 		indentation,
 		dryRunFlag.name,
+		checkFlag.name,
 		strictFlag.alias,
 		strictFlag.name,
 	)
-	fmt.Printf("%s [%s | %s]\n",
+	fmt.Printf("%s [%s [%s <lines>]] [%s] [%s]\n",
+		indentation,
+		diffFlag.name,
+		diffContextOption.name,
+		interactiveFlag.name,
+		watchFlag.name,
+	)
+	fmt.Printf("%s [%s | %s] [%s] [%s]\n",
 		indentation,
 		invertFlag.alias,
 		invertFlag.name,
+		simultaneousFlag.name,
+		fsyncFlag.name,
 	)
 	fmt.Printf("%s [%s | %s] [%s | %s]\n",
 		indentation,
@@ -129,6 +207,33 @@ func printInterface() {
 		mappingOption.alias,
 		mappingOption.name,
 	)
+	fmt.Printf("%s [%s <name>] [%s <format>]\n",
+		indentation,
+		stdinNameOption.name,
+		reportOption.name,
+	)
+	fmt.Printf("%s [%s | %s <jobs>]\n",
+		indentation,
+		jobsOption.alias,
+		jobsOption.name,
+	)
+	fmt.Printf("%s [%s <glob>] [%s <glob>] [%s <extension>]\n",
+		indentation,
+		includeOption.name,
+		excludeOption.name,
+		extensionOption.name,
+	)
+	fmt.Printf("%s [%s <depth>] [%s <policy>] [%s]\n",
+		indentation,
+		maxDepthOption.name,
+		symlinksOption.name,
+		resolveSymlinksFlag.name,
+	)
+	fmt.Printf("%s [%s] [%s]\n",
+		indentation,
+		noDefaultExcludesFlag.name,
+		noIgnoreFlag.name,
+	)
 	fmt.Printf("%s <files>\n", indentation)
 }
 
@@ -4,29 +4,98 @@ import (
 	"bytes"
 
 	"github.com/ericcornelissen/wordrow/internal/errors"
-	"github.com/ericcornelissen/wordrow/internal/logger"
 )
 
-// AddValuesToMap adds the values defined to the provided map such that each
-// value other than the last is mapped to te last value.
-func AddValuesToMap(mapping map[string]string, values [][]byte) {
+// Mapping represents a single mapping `From` one string `To` another.
+type Mapping struct {
+	// The string to be replaced.
+	From string
+
+	// The string to replace `From` with.
+	To string
+
+	// The source the Mapping is defined in, e.g. the path of a mapping file. It
+	// is empty if the source is unknown.
+	Source string
+
+	// The line in the Source at which the Mapping is defined, starting at 1. It
+	// is zero if the line is unknown.
+	Line int
+
+	// Whether `From` is matched case sensitively. If so, `To` is used as is
+	// instead of taking on the capitalization of the string it replaces.
+	CaseSensitive bool
+
+	// A description of the Mapping, e.g. the reason for the replacement. It is
+	// empty if the Mapping has no description.
+	Description string
+
+	// The severity of the Mapping, one of "error", "warning", or "info". It is
+	// empty if the Mapping has no severity.
+	Severity string
+}
+
+// MappingList represents an ordered list of mappings with at most one Mapping
+// from any value. The zero value is an empty list ready to use.
+type MappingList struct {
+	// The mappings, in the order in which they were first set.
+	mappings []Mapping
+
+	// The index of the Mapping in `mappings` from every value.
+	index map[string]int
+}
+
+// AddValues adds the values defined on `line` to the list such that each value
+// other than the last is mapped to the last value. A value that is already
+// present in the list keeps its position but gets the new value.
+func (l *MappingList) AddValues(values [][]byte, line int) {
 	last := len(values) - 1
 	to := string(values[last])
 	for _, from := range values[0:last] {
-		mapping[string(from)] = to
+		l.Set(Mapping{
+			From: string(from),
+			To:   to,
+			Line: line,
+		})
 	}
 }
 
-// MergeMaps merges the maps `target` and `other` into map `target`. Keys
-// present in both `target` and `other` will end up with the value of `other`.
-func MergeMaps(target, other map[string]string) {
-	for key, value := range other {
-		if oldValue, present := target[key]; present {
-			logger.Debugf("Overwriting '%s': from '%s' to '%s'", key, oldValue, value)
-		}
+// Mappings returns the mappings in the list, in order.
+func (l *MappingList) Mappings() []Mapping {
+	return l.mappings
+}
+
+// Set sets `mapping` in the list. If the list already contains a Mapping from
+// the same value it is overwritten in place, otherwise `mapping` is appended.
+func (l *MappingList) Set(mapping Mapping) {
+	if l.index == nil {
+		l.index = make(map[string]int)
+	}
 
-		target[key] = value
+	if i, ok := l.index[mapping.From]; ok {
+		l.mappings[i] = mapping
+		return
 	}
+
+	l.index[mapping.From] = len(l.mappings)
+	l.mappings = append(l.mappings, mapping)
+}
+
+// MergeMappings merges the mappings `target` and `other` into `target`. A from
+// value present in both `target` and `other` keeps its position in `target` but
+// will end up with the value of `other`. Other mappings of `other` are appended
+// to `target` in order.
+func MergeMappings(target, other []Mapping) []Mapping {
+	var list MappingList
+	for _, mapping := range target {
+		list.Set(mapping)
+	}
+
+	for _, mapping := range other {
+		list.Set(mapping)
+	}
+
+	return list.Mappings()
 }
 
 // TrimValues output all input values trimmed, or an error if any of the trimmed
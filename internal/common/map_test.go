@@ -5,120 +5,202 @@ import (
 	"testing"
 )
 
-func TestAddValuesToMap(t *testing.T) {
+func TestMappingListAddValues(t *testing.T) {
 	t.Run("two values", func(t *testing.T) {
 		from, to := "baz", "bar"
 
-		mapping := make(map[string]string, 1)
 		values := [][]byte{
 			[]byte(from),
 			[]byte(to),
 		}
 
-		AddValuesToMap(mapping, values)
+		var list MappingList
+		list.AddValues(values, 1)
 
-		if mapping[from] != to {
-			t.Errorf("Unexpected value for %s (got '%s')", from, to)
+		mappings := list.Mappings()
+		if len(mappings) != 1 {
+			t.Fatalf("Unexpected number of mappings (got %d)", len(mappings))
+		}
+
+		if mappings[0].From != from || mappings[0].To != to {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", mappings[0].From, mappings[0].To)
+		}
+
+		if mappings[0].Line != 1 {
+			t.Errorf("Unexpected line (got %d)", mappings[0].Line)
 		}
 	})
 	t.Run("many values", func(t *testing.T) {
 		from1, from2, to := "hello", "hey", "howdy"
 
-		mapping := make(map[string]string, 1)
 		values := [][]byte{
 			[]byte(from1),
 			[]byte(from2),
 			[]byte(to),
 		}
 
-		AddValuesToMap(mapping, values)
+		var list MappingList
+		list.AddValues(values, 1)
+
+		mappings := list.Mappings()
+		if len(mappings) != 2 {
+			t.Fatalf("Unexpected number of mappings (got %d)", len(mappings))
+		}
 
-		if mapping[from1] != to {
-			t.Errorf("Unexpected value for %s (got '%s')", from1, to)
+		if mappings[0].From != from1 || mappings[0].To != to {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", mappings[0].From, mappings[0].To)
 		}
 
-		if mapping[from2] != to {
-			t.Errorf("Unexpected value for %s (got '%s')", from2, to)
+		if mappings[1].From != from2 || mappings[1].To != to {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", mappings[1].From, mappings[1].To)
+		}
+	})
+	t.Run("existing value", func(t *testing.T) {
+		var list MappingList
+		list.Set(Mapping{From: "foo", To: "bar"})
+		list.Set(Mapping{From: "hello", To: "world"})
+		values := [][]byte{
+			[]byte("foo"),
+			[]byte("baz"),
+		}
+
+		list.AddValues(values, 3)
+
+		mappings := list.Mappings()
+		if len(mappings) != 2 {
+			t.Fatalf("Unexpected number of mappings (got %d)", len(mappings))
+		}
+
+		if mappings[0].From != "foo" || mappings[0].To != "baz" {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", mappings[0].From, mappings[0].To)
+		}
+
+		if mappings[0].Line != 3 {
+			t.Errorf("Unexpected line of the first mapping (got %d)", mappings[0].Line)
 		}
 	})
 }
 
-func TestMergeMaps(t *testing.T) {
-	t.Run("merge disjoint maps", func(t *testing.T) {
-		target := make(map[string]string, 1)
-		other := make(map[string]string, 1)
-		target["foo"] = "bar"
-		other["hello"] = "world"
+func TestMappingListSet(t *testing.T) {
+	t.Run("new values", func(t *testing.T) {
+		var list MappingList
+		list.Set(Mapping{From: "b", To: "1"})
+		list.Set(Mapping{From: "a", To: "2"})
+
+		mappings := list.Mappings()
+		if len(mappings) != 2 {
+			t.Fatalf("Unexpected number of mappings (got %d)", len(mappings))
+		}
 
-		MergeMaps(target, other)
+		if mappings[0].From != "b" || mappings[1].From != "a" {
+			t.Errorf("Unexpected order (got '%s' then '%s')", mappings[0].From, mappings[1].From)
+		}
+	})
+	t.Run("existing value", func(t *testing.T) {
+		var list MappingList
+		list.Set(Mapping{From: "foo", To: "bar"})
+		list.Set(Mapping{From: "hello", To: "world"})
+		list.Set(Mapping{From: "foo", To: "baz"})
+		list.Set(Mapping{From: "hey", To: "there"})
+
+		mappings := list.Mappings()
+		if len(mappings) != 3 {
+			t.Fatalf("Unexpected number of mappings (got %d)", len(mappings))
+		}
+
+		if mappings[0].From != "foo" || mappings[0].To != "baz" {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", mappings[0].From, mappings[0].To)
+		}
+
+		if mappings[2].From != "hey" || mappings[2].To != "there" {
+			t.Errorf("Unexpected last mapping (got '%s' to '%s')", mappings[2].From, mappings[2].To)
+		}
+	})
+}
+
+func TestMergeMappings(t *testing.T) {
+	t.Run("merge disjoint mappings", func(t *testing.T) {
+		target := []Mapping{{From: "foo", To: "bar"}}
+		other := []Mapping{{From: "hello", To: "world"}}
+
+		target = MergeMappings(target, other)
 		if len(target) != 2 {
-			t.Errorf("Unexpected size of target map (got %d)", len(target))
+			t.Fatalf("Unexpected size of target (got %d)", len(target))
 		}
 
-		if target["foo"] != "bar" {
-			t.Errorf("Unexpected value for key 'foo' (got '%s')", target["foo"])
+		if target[0].From != "foo" || target[0].To != "bar" {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", target[0].From, target[0].To)
 		}
 
-		if target["hello"] != "world" {
-			t.Errorf("Unexpected value for key 'hello' (got '%s')", target["hello"])
+		if target[1].From != "hello" || target[1].To != "world" {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", target[1].From, target[1].To)
 		}
 	})
 	t.Run("other overrides in target", func(t *testing.T) {
-		target := make(map[string]string, 1)
-		other := make(map[string]string, 1)
-		target["foo"] = "bar"
-		other["foo"] = "baz"
+		target := []Mapping{
+			{From: "foo", To: "bar"},
+			{From: "hello", To: "world"},
+		}
+		other := []Mapping{{From: "foo", To: "baz"}}
 
-		MergeMaps(target, other)
-		if len(target) != 1 {
-			t.Errorf("Unexpected size of target map (got %d)", len(target))
+		target = MergeMappings(target, other)
+		if len(target) != 2 {
+			t.Fatalf("Unexpected size of target (got %d)", len(target))
 		}
 
-		if target["foo"] != "baz" {
-			t.Errorf("Unexpected value for key 'foo' (got '%s')", target["foo"])
+		if target[0].From != "foo" || target[0].To != "baz" {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", target[0].From, target[0].To)
 		}
 	})
 	t.Run("other is not changed", func(t *testing.T) {
-		target := make(map[string]string, 1)
-		other := make(map[string]string, 1)
-		target["foo"] = "bar"
-		other["hello"] = "world"
+		target := []Mapping{{From: "foo", To: "bar"}}
+		other := []Mapping{{From: "hello", To: "world"}}
 
-		MergeMaps(target, other)
+		MergeMappings(target, other)
 		if len(other) != 1 {
-			t.Errorf("Unexpected size of other map (got %d)", len(other))
+			t.Fatalf("Unexpected size of other (got %d)", len(other))
 		}
 
-		if other["hello"] != "world" {
-			t.Errorf("Unexpected value for key 'hello' (got '%s')", other["hello"])
+		if other[0].From != "hello" || other[0].To != "world" {
+			t.Errorf("Unexpected mapping in other (got '%s' to '%s')", other[0].From, other[0].To)
 		}
 	})
 	t.Run("target is empty", func(t *testing.T) {
-		target := make(map[string]string)
-		other := make(map[string]string, 1)
-		other["hello"] = "world"
+		other := []Mapping{{From: "hello", To: "world"}}
 
-		MergeMaps(target, other)
+		target := MergeMappings(nil, other)
 		if len(target) != 1 {
-			t.Errorf("Unexpected size of target map (got %d)", len(target))
+			t.Fatalf("Unexpected size of target (got %d)", len(target))
 		}
 
-		if target["hello"] != "world" {
-			t.Errorf("Unexpected value for key 'hello' (got '%s')", target["hello"])
+		if target[0].From != "hello" || target[0].To != "world" {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", target[0].From, target[0].To)
 		}
 	})
 	t.Run("other is empty", func(t *testing.T) {
-		target := make(map[string]string, 1)
-		other := make(map[string]string)
-		target["foo"] = "bar"
+		target := []Mapping{{From: "foo", To: "bar"}}
 
-		MergeMaps(target, other)
+		target = MergeMappings(target, nil)
 		if len(target) != 1 {
-			t.Errorf("Unexpected size of target map (got %d)", len(target))
+			t.Fatalf("Unexpected size of target (got %d)", len(target))
+		}
+
+		if target[0].From != "foo" || target[0].To != "bar" {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", target[0].From, target[0].To)
+		}
+	})
+	t.Run("order is maintained", func(t *testing.T) {
+		other := []Mapping{
+			{From: "b", To: "1"},
+			{From: "a", To: "2"},
+			{From: "c", To: "3"},
 		}
 
-		if target["foo"] != "bar" {
-			t.Errorf("Unexpected value for key 'foo' (got '%s')", target["foo"])
+		target := MergeMappings(nil, other)
+		for i, mapping := range target {
+			if mapping != other[i] {
+				t.Errorf("Unexpected mapping at %d (got '%s' to '%s')", i, mapping.From, mapping.To)
+			}
 		}
 	})
 }
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+)
+
+// Severities are the known severities of a Mapping.
+var Severities = []string{"error", "warning", "info"}
+
+// MetadataFields are the fields of the metadata of a rule in a mapping file,
+// see Metadata.
+var MetadataFields = []string{
+	"description", "severity", "case-sensitive", "enabled",
+}
+
+// RuleFields are the fields a rule in a mapping file may have, i.e. its `from`
+// and `to` values and its metadata.
+var RuleFields = append([]string{"from", "to"}, MetadataFields...)
+
+// Metadata represents the metadata of a rule in a mapping file. A field is nil
+// if it is not set, in which case the default is used.
+type Metadata struct {
+	// A description of the rule, empty by default.
+	Description *string `json:"description" yaml:"description"`
+
+	// The severity of the rule, one of the Severities or empty by default.
+	Severity *string `json:"severity" yaml:"severity"`
+
+	// Whether the rule matches case sensitively, not by default.
+	CaseSensitive *bool `json:"case-sensitive" yaml:"case-sensitive"`
+
+	// Whether the rule is used, enabled by default.
+	Enabled *bool `json:"enabled" yaml:"enabled"`
+}
+
+// IsOneOf checks whether `value` is one of the `options`.
+func IsOneOf(value string, options []string) bool {
+	for _, option := range options {
+		if value == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Inherit gets the Metadata where every field that is not set is taken from the
+// `parent` Metadata.
+func (m Metadata) Inherit(parent Metadata) Metadata {
+	if m.Description == nil {
+		m.Description = parent.Description
+	}
+
+	if m.Severity == nil {
+		m.Severity = parent.Severity
+	}
+
+	if m.CaseSensitive == nil {
+		m.CaseSensitive = parent.CaseSensitive
+	}
+
+	if m.Enabled == nil {
+		m.Enabled = parent.Enabled
+	}
+
+	return m
+}
+
+// Validate checks that the Metadata is valid.
+//
+// The error will be set if the severity is set but not one of the Severities.
+func (m *Metadata) Validate() error {
+	if m.Severity != nil && !IsOneOf(*m.Severity, Severities) {
+		return errors.Newf("Unknown severity '%s'", *m.Severity)
+	}
+
+	return nil
+}
+
+// IsEnabled checks whether a rule with the Metadata is used.
+func (m *Metadata) IsEnabled() bool {
+	return m.Enabled == nil || *m.Enabled
+}
+
+// ApplyTo sets the description, severity, and case sensitivity of the `mapping`
+// to those of the Metadata. The description is trimmed.
+func (m *Metadata) ApplyTo(mapping *Mapping) {
+	if m.Description != nil {
+		mapping.Description = stringsx.TrimSpace(*m.Description)
+	}
+
+	if m.Severity != nil {
+		mapping.Severity = *m.Severity
+	}
+
+	if m.CaseSensitive != nil {
+		mapping.CaseSensitive = *m.CaseSensitive
+	}
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestIsOneOf(t *testing.T) {
+	if !IsOneOf("warning", Severities) {
+		t.Error("Expected 'warning' to be one of the severities")
+	}
+
+	if IsOneOf("fatal", Severities) {
+		t.Error("Expected 'fatal' not to be one of the severities")
+	}
+}
+
+func TestMetadataInherit(t *testing.T) {
+	description, severity := "Use the full name", "info"
+	yes, no := true, false
+
+	parent := Metadata{
+		Description:   &description,
+		Severity:      &severity,
+		CaseSensitive: &yes,
+		Enabled:       &yes,
+	}
+
+	m := Metadata{Enabled: &no}.Inherit(parent)
+	if m.Description != &description || m.Severity != &severity {
+		t.Errorf("Unexpected description or severity (got %+v)", m)
+	}
+
+	if m.CaseSensitive != &yes || m.Enabled != &no {
+		t.Errorf("Unexpected case sensitivity or enabled (got %+v)", m)
+	}
+}
+
+func TestMetadataValidate(t *testing.T) {
+	for _, severity := range Severities {
+		m := Metadata{Severity: &severity}
+		if err := m.Validate(); err != nil {
+			t.Errorf("Unexpected error for '%s' (got '%s')", severity, err)
+		}
+	}
+
+	if err := new(Metadata).Validate(); err != nil {
+		t.Errorf("Unexpected error for no severity (got '%s')", err)
+	}
+
+	severity := "fatal"
+	m := Metadata{Severity: &severity}
+	if err := m.Validate(); err == nil || err.Error() != "Unknown severity 'fatal'" {
+		t.Errorf("Unexpected error for '%s' (got '%v')", severity, err)
+	}
+}
+
+func TestMetadataIsEnabled(t *testing.T) {
+	yes, no := true, false
+
+	if !new(Metadata).IsEnabled() {
+		t.Error("Expected a rule to be enabled by default")
+	}
+
+	if m := (Metadata{Enabled: &yes}); !m.IsEnabled() {
+		t.Error("Expected an enabled rule to be enabled")
+	}
+
+	if m := (Metadata{Enabled: &no}); m.IsEnabled() {
+		t.Error("Expected a disabled rule not to be enabled")
+	}
+}
+
+func TestMetadataApplyTo(t *testing.T) {
+	description, severity, yes := " Use the full name\n", "error", true
+	m := Metadata{
+		Description:   &description,
+		Severity:      &severity,
+		CaseSensitive: &yes,
+	}
+
+	mapping := Mapping{From: "JS", To: "JavaScript"}
+	m.ApplyTo(&mapping)
+
+	if mapping.Description != "Use the full name" {
+		t.Errorf("Unexpected description (got '%s')", mapping.Description)
+	}
+
+	if mapping.Severity != severity || !mapping.CaseSensitive {
+		t.Errorf("Unexpected mapping (got %+v)", mapping)
+	}
+}
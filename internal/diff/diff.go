@@ -0,0 +1,288 @@
+/*
+Package diff provides a function to compute the differences between two texts
+in the unified diff format. The output can be applied using `git apply` or
+`patch -p1` from the working directory.
+
+	var original, updated []byte
+	Unified("file.txt", original, updated, 3)
+
+The differences are computed line by line using the Myers diff algorithm.
+*/
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+// The message to indicate that a line is not terminated by a newline.
+const noNewlineMessage = "\\ No newline at end of file\n"
+
+// The operation type represents what happens to a line going from one text to
+// another text.
+type operation int
+
+const (
+	// The line is present in both texts.
+	opEqual operation = iota
+
+	// The line is present in the first text only.
+	opDelete
+
+	// The line is present in the second text only.
+	opInsert
+)
+
+// The prefix of a line for each operation in a unified diff.
+var prefixes = map[operation]byte{
+	opEqual:  ' ',
+	opDelete: '-',
+	opInsert: '+',
+}
+
+// An edit represents what happens to a single line going from one text to
+// another text.
+type edit struct {
+	// The operation on the line.
+	op operation
+
+	// The index of the line in the first text. For an insertion this is the index
+	// of the first line after the insertion.
+	a int
+
+	// The index of the line in the second text. For a deletion this is the index
+	// of the first line after the deletion.
+	b int
+}
+
+// Split `s` into lines. Every line includes its newline character, except for
+// the last line if `s` does not end with a newline.
+func splitLines(s []byte) (lines [][]byte) {
+	for len(s) > 0 {
+		i := bytes.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+
+		lines = append(lines, s[:i])
+		s = s[i:]
+	}
+
+	return lines
+}
+
+// Compute the shortest list of edits that turns the lines `a` into the lines
+// `b` using the Myers diff algorithm.
+func computeEdits(a, b [][]byte) []edit {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+
+	// For every number of differences d, `trace` holds the furthest reaching
+	// index in `a` for every diagonal k in [-d, d] before step d.
+	var trace [][]int
+	v := make([]int, 2*limit+3)
+
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && bytes.Equal(a[x], b[y]) {
+				x, y = x+1, y+1
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	edits := make([]edit, 0, limit)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		get := func(k int) int { return trace[d][k+d] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && get(k-1) < get(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = get(prevK)
+		}
+
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, edit{op: opEqual, a: x, b: y})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				edits = append(edits, edit{op: opInsert, a: x, b: y})
+			} else {
+				x--
+				edits = append(edits, edit{op: opDelete, a: x, b: y})
+			}
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// Find the hunks in the `edits`, i.e. the groups of changes with (at most)
+// `context` unchanged lines around them. Each hunk is given as a start and end
+// index (exclusive) in `edits`.
+func findHunks(edits []edit, context int) (hunks [][2]int) {
+	i := 0
+	for i < len(edits) {
+		for i < len(edits) && edits[i].op == opEqual {
+			i++
+		}
+
+		if i == len(edits) {
+			break
+		}
+
+		start, end := maxInt(i-context, 0), i
+		for j := i; j < len(edits); {
+			if edits[j].op != opEqual {
+				j++
+				end = j
+				continue
+			}
+
+			k := j
+			for k < len(edits) && edits[k].op == opEqual {
+				k++
+			}
+
+			if k == len(edits) || k-j > 2*context {
+				break
+			}
+
+			j = k
+		}
+
+		end = minInt(end+context, len(edits))
+		hunks = append(hunks, [2]int{start, end})
+		i = end
+	}
+
+	return hunks
+}
+
+// Format a hunk range as it appears in a hunk header, given the index of the
+// first line of the range and the number of lines in the range.
+func formatRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// Write a hunk consisting of the `edits` between the lines `a` and `b` to `bb`.
+func writeHunk(bb *bytes.Buffer, a, b [][]byte, edits []edit) {
+	countA, countB := 0, 0
+	for _, e := range edits {
+		if e.op != opInsert {
+			countA++
+		}
+
+		if e.op != opDelete {
+			countB++
+		}
+	}
+
+	fmt.Fprintf(
+		bb,
+		"@@ -%s +%s @@\n",
+		formatRange(edits[0].a, countA),
+		formatRange(edits[0].b, countB),
+	)
+
+	for _, e := range edits {
+		var line []byte
+		if e.op == opInsert {
+			line = b[e.b]
+		} else {
+			line = a[e.a]
+		}
+
+		bb.WriteByte(prefixes[e.op])
+		bb.Write(line)
+		if !bytes.HasSuffix(line, []byte{'\n'}) {
+			bb.WriteByte('\n')
+			bb.WriteString(noNewlineMessage)
+		}
+	}
+}
+
+// Get the path of the file `name` as it appears in the header of a diff. This is
+// the path relative to the working directory, using forward slashes. If the
+// file is not inside the working directory it is the absolute path without its
+// root, so that the diff applies from the root.
+func headerPath(name string) string {
+	path := filepath.Clean(name)
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			rel, err := filepath.Rel(wd, abs)
+			if err == nil && rel != ".." && !stringsx.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return filepath.ToSlash(rel)
+			}
+		}
+
+		path = abs
+	}
+
+	path = filepath.ToSlash(path[len(filepath.VolumeName(path)):])
+	return stringsx.TrimLeft(path, "/")
+}
+
+// Unified computes the differences between the texts `a` and `b` of the file
+// `name` in the unified diff format, with `context` lines of context around
+// every change. The file name is prefixed by "a/" and "b/" in the output, see
+// headerPath. If the texts are equal the output is empty.
+func Unified(name string, a, b []byte, context int) []byte {
+	var bb bytes.Buffer
+
+	linesA, linesB := splitLines(a), splitLines(b)
+	edits := computeEdits(linesA, linesB)
+
+	hunks := findHunks(edits, maxInt(context, 0))
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	name = headerPath(name)
+	fmt.Fprintf(&bb, "--- a/%s\n", name)
+	fmt.Fprintf(&bb, "+++ b/%s\n", name)
+	for _, hunk := range hunks {
+		writeHunk(&bb, linesA, linesB, edits[hunk[0]:hunk[1]])
+	}
+
+	return bb.Bytes()
+}
@@ -0,0 +1,314 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Apply the unified diff `patch` to `a`. This is a minimal implementation that
+// assumes the `patch` is correctly formatted.
+func apply(t *testing.T, a, patch []byte) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	if len(patch) == 0 {
+		return a
+	}
+
+	linesA := splitLines(a)
+	lines := splitLines(patch)
+	if len(lines) < 2 {
+		t.Fatalf("Patch is missing a header (got '%s')", patch)
+	}
+
+	next := 0
+	for i := 2; i < len(lines); i++ {
+		line := string(lines[i])
+		if !strings.HasPrefix(line, "@@ ") {
+			t.Fatalf("Expected a hunk header (got '%s')", line)
+		}
+
+		var start, count int
+		header := strings.Fields(line)[1][1:]
+		parts := strings.Split(header, ",")
+		start, _ = strconv.Atoi(parts[0])
+		count, _ = strconv.Atoi(parts[1])
+		if count > 0 {
+			start--
+		}
+
+		for ; next < start; next++ {
+			out.Write(linesA[next])
+		}
+
+		for i+1 < len(lines) && !bytes.HasPrefix(lines[i+1], []byte("@@ ")) {
+			i++
+
+			line := lines[i]
+			noNewline := i+1 < len(lines) && string(lines[i+1]) == noNewlineMessage
+			if noNewline {
+				line = line[:len(line)-1]
+				i++
+			}
+
+			switch line[0] {
+			case ' ':
+				out.Write(line[1:])
+				next++
+			case '-':
+				next++
+			case '+':
+				out.Write(line[1:])
+			}
+		}
+	}
+
+	for ; next < len(linesA); next++ {
+		out.Write(linesA[next])
+	}
+
+	return out.Bytes()
+}
+
+func ExampleUnified() {
+	a := []byte("Hello world!\nI have a dog.\n")
+	b := []byte("Hello world!\nI have a cat.\n")
+
+	fmt.Print(string(Unified("file.txt", a, b, 3)))
+	// Output:
+	// --- a/file.txt
+	// +++ b/file.txt
+	// @@ -1,2 +1,2 @@
+	//  Hello world!
+	// -I have a dog.
+	// +I have a cat.
+}
+
+func TestSplitLines(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		lines := splitLines([]byte(""))
+		if len(lines) != 0 {
+			t.Errorf("Unexpected number of lines (got %d)", len(lines))
+		}
+	})
+	t.Run("trailing newline", func(t *testing.T) {
+		lines := splitLines([]byte("foo\nbar\n"))
+		if len(lines) != 2 {
+			t.Fatalf("Unexpected number of lines (got %d)", len(lines))
+		}
+
+		if string(lines[0]) != "foo\n" || string(lines[1]) != "bar\n" {
+			t.Errorf("Unexpected lines (got %q)", lines)
+		}
+	})
+	t.Run("no trailing newline", func(t *testing.T) {
+		lines := splitLines([]byte("foo\nbar"))
+		if len(lines) != 2 {
+			t.Fatalf("Unexpected number of lines (got %d)", len(lines))
+		}
+
+		if string(lines[0]) != "foo\n" || string(lines[1]) != "bar" {
+			t.Errorf("Unexpected lines (got %q)", lines)
+		}
+	})
+}
+
+func TestUnified(t *testing.T) {
+	t.Run("no differences", func(t *testing.T) {
+		a := []byte("foo\nbar\n")
+
+		result := Unified("file.txt", a, a, 3)
+		if len(result) != 0 {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("both empty", func(t *testing.T) {
+		result := Unified("file.txt", nil, nil, 3)
+		if len(result) != 0 {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("file name", func(t *testing.T) {
+		result := Unified("./docs/../README.md", []byte("foo\n"), []byte("bar\n"), 3)
+
+		expected := "--- a/README.md\n+++ b/README.md\n@@ -1,1 +1,1 @@\n-foo\n+bar\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("context", func(t *testing.T) {
+		a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+		b := []byte("1\n2\n3\n4\nfive\n6\n7\n8\n9\n")
+
+		result := Unified("f", a, b, 1)
+
+		expected := "--- a/f\n+++ b/f\n@@ -4,3 +4,3 @@\n 4\n-5\n+five\n 6\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+
+		result = Unified("f", a, b, 0)
+
+		expected = "--- a/f\n+++ b/f\n@@ -5,1 +5,1 @@\n-5\n+five\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("multiple hunks", func(t *testing.T) {
+		a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+		b := []byte("one\n2\n3\n4\n5\n6\n7\n8\nnine\n")
+
+		result := Unified("f", a, b, 1)
+
+		expected := "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n-1\n+one\n 2\n@@ -8,2 +8,2 @@\n 8\n-9\n+nine\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("merged hunks", func(t *testing.T) {
+		a := []byte("1\n2\n3\n4\n5\n")
+		b := []byte("one\n2\n3\nfour\n5\n")
+
+		result := Unified("f", a, b, 1)
+
+		expected := "--- a/f\n+++ b/f\n@@ -1,5 +1,5 @@\n-1\n+one\n 2\n 3\n-4\n+four\n 5\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("no newline at end of file", func(t *testing.T) {
+		result := Unified("f", []byte("foo\nbar"), []byte("foo\nbaz"), 3)
+
+		expected := "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n foo\n-bar\n\\ No newline at end of file\n+baz\n\\ No newline at end of file\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("from empty", func(t *testing.T) {
+		result := Unified("f", nil, []byte("foo\n"), 3)
+
+		expected := "--- a/f\n+++ b/f\n@@ -0,0 +1,1 @@\n+foo\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+	t.Run("to empty", func(t *testing.T) {
+		result := Unified("f", []byte("foo\n"), nil, 3)
+
+		expected := "--- a/f\n+++ b/f\n@@ -1,1 +0,0 @@\n-foo\n"
+		if string(result) != expected {
+			t.Errorf("Unexpected output (got '%s')", result)
+		}
+	})
+}
+
+func TestUnifiedApplies(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	words := []string{"foo\n", "bar\n", "baz\n", "hello\n", "world"}
+
+	randomText := func() []byte {
+		var bb bytes.Buffer
+		for i := random.Intn(20); i > 0; i-- {
+			bb.WriteString(words[random.Intn(len(words)-1)])
+		}
+
+		if random.Intn(2) == 0 {
+			bb.WriteString(words[len(words)-1])
+		}
+
+		return bb.Bytes()
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomText(), randomText()
+		context := random.Intn(4)
+
+		patch := Unified("f", a, b, context)
+		if result := apply(t, a, patch); !bytes.Equal(result, b) {
+			t.Fatalf("Patch does not apply\na: %q\nb: %q\npatch:\n%s", a, b, patch)
+		}
+	}
+}
+
+func TestHeaderPath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Could not get the working directory (%s)", err)
+	}
+
+	root := filepath.ToSlash(wd[len(filepath.VolumeName(wd)):])
+	parent := strings.TrimLeft(filepath.ToSlash(filepath.Dir(root)), "/")
+
+	cases := map[string]string{
+		"file.txt":                         "file.txt",
+		"./docs/../file.txt":               "file.txt",
+		filepath.Join("docs", "file.txt"):  "docs/file.txt",
+		filepath.Join(wd, "file.txt"):      "file.txt",
+		filepath.Join("..", "file.txt"):    parent + "/file.txt",
+		filepath.Join(wd, "..", "foo.txt"): parent + "/foo.txt",
+	}
+
+	for name, expected := range cases {
+		if path := headerPath(name); path != expected {
+			t.Errorf("Unexpected path for '%s' (got '%s')", name, path)
+		}
+	}
+}
+
+func TestUnifiedGitApply(t *testing.T) {
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Could not get the working directory (%s)", err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Could not change the working directory (%s)", err)
+	}
+
+	defer os.Chdir(wd)
+
+	cases := []struct{ a, b string }{
+		{a: "Hello world!\nI have a dog.\n", b: "Hello world!\nI have a cat.\n"},
+		{a: "foo\nbar", b: "foo\nbaz"},
+		{a: "foo\nbar\n", b: "foo\nbar"},
+		{a: "foo\n", b: ""},
+		{a: "", b: "foo\n"},
+	}
+
+	for i, c := range cases {
+		name := fmt.Sprintf("file%d.txt", i)
+		if err := ioutil.WriteFile(name, []byte(c.a), 0644); err != nil {
+			t.Fatalf("Could not create a temporary file (%s)", err)
+		}
+
+		for _, path := range []string{name, "./" + name, filepath.Join(dir, name)} {
+			patch := Unified(path, []byte(c.a), []byte(c.b), 3)
+
+			cmd := exec.Command(git, "apply", "--check", "-")
+			cmd.Stdin = bytes.NewReader(patch)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("Patch does not apply (%s)\n%s\npatch:\n%s", err, output, patch)
+			}
+		}
+	}
+}
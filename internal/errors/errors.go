@@ -18,3 +18,10 @@ func Newf(text string, args ...interface{}) error {
 	formattedText := fmt.Sprintf(text, args...)
 	return New(formattedText)
 }
+
+// Wrapf creates a new `error` with a formatted error text followed by the text
+// of the `cause` in parentheses. The `cause` can be retrieved using Unwrap.
+func Wrapf(cause error, text string, args ...interface{}) error {
+	formattedText := fmt.Sprintf(text, args...)
+	return fmt.Errorf("%s (%w)", formattedText, cause)
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -17,6 +18,12 @@ func ExampleNewf() {
 	// Output: foobar
 }
 
+func ExampleWrapf() {
+	err := Wrapf(New("baz"), "foo%s", "bar")
+	fmt.Print(err)
+	// Output: foobar (baz)
+}
+
 func TestNew(t *testing.T) {
 	err := New("foobar")
 
@@ -40,3 +47,20 @@ func TestNewf(t *testing.T) {
 		t.Error("not good")
 	}
 }
+
+func TestWrapf(t *testing.T) {
+	cause := New("baz")
+	err := Wrapf(cause, "foo%s", "bar")
+
+	if err == nil {
+		t.Fatal("Error should not be nil")
+	}
+
+	if err.Error() != "foobar (baz)" {
+		t.Error("not good")
+	}
+
+	if errors.Unwrap(err) != cause {
+		t.Error("The cause should be kept")
+	}
+}
@@ -1,6 +1,14 @@
 package fs
 
-import "os"
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// The file mode bits of a File that are preserved when writing to it.
+const preservedMode = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
 
 // File is a struct, representing a file, that implements ReadWriter.
 type File struct {
@@ -9,37 +17,192 @@ type File struct {
 
 	// The absolute path of the File.
 	path string
+
+	// Flag indicating whether writes should be synced to the storage device.
+	sync bool
+
+	// The state of the File as last written, or nil if it was not written.
+	written os.FileInfo
 }
 
 // Close closes the operating system (OS) handle for this File. After calling
 // Close the File cannot be used for reading or writing anymore.
-func (f File) Close() error {
+func (f *File) Close() error {
 	return f.handle.Close()
 }
 
 // Read reads the contents of the File into `data`. It returns the amount of
 // bytes read in the first return value. It may return an error in the second
 // return value if reading failed.
-func (f File) Read(data []byte) (n int, err error) {
+func (f *File) Read(data []byte) (n int, err error) {
 	return f.handle.Read(data)
 }
 
 // String returns the absolute path of the File.
-func (f File) String() string {
+func (f *File) String() string {
 	return f.path
 }
 
-// Write empties the File and writes `data` into it. It returns the amount of
-// bytes written in the first return value. It may return an error in the second
-// return value if emptying or writing failed.
-func (f File) Write(data []byte) (n int, err error) {
-	if err := f.handle.Truncate(0); err != nil {
+// Size returns the size of the File in bytes.
+func (f *File) Size() (int64, error) {
+	info, err := f.handle.Stat()
+	if err != nil {
 		return 0, err
 	}
 
-	if _, err := f.handle.Seek(0, 0); err != nil {
-		return 0, err
+	return info.Size(), nil
+}
+
+// Written returns the state of the File right after it was last written by
+// Write or Rewrite, or nil if it was not written. Unlike the state of the file
+// at the path of the File, it is not affected by later changes to that file.
+func (f *File) Written() os.FileInfo {
+	return f.written
+}
+
+// Write replaces the contents of the File by `data`. It returns the amount of
+// bytes written in the first return value. It may return an error in the second
+// return value if writing failed.
+//
+// The `data` is first written to a temporary file in the same directory as the
+// File, which then replaces the File in a single atomic operation. Hence, the
+// File either has its original contents or `data`, even if the program is
+// interrupted. The permissions, and where possible the ownership and extended
+// attributes, of the File are preserved. Note that hard links to the File will
+// keep referring to the original contents.
+//
+// If no temporary file can be created in the directory of the File because its
+// permissions do not allow it, the File is overwritten in place instead. This
+// is not atomic, but keeps the File itself, including its hard links.
+func (f *File) Write(data []byte) (n int, err error) {
+	err = f.Rewrite(func(w io.Writer) (bool, error) {
+		n, err = w.Write(data)
+		return true, err
+	})
+
+	return n, err
+}
+
+// Rewrite replaces the contents of the File by the data that `write` writes to
+// the io.Writer it is given, like Write. This allows for the new contents to be
+// written while the File is being read. If `write` returns false or an error,
+// the File is left as is.
+func (f *File) Rewrite(write func(w io.Writer) (bool, error)) (err error) {
+	path, err := filepath.EvalSymlinks(f.path)
+	if err != nil {
+		return err
 	}
 
-	return f.handle.Write(data)
+	info, err := f.handle.Stat()
+	if err != nil {
+		return err
+	}
+
+	dir, base := filepath.Split(path)
+	temp, err := ioutil.TempFile(filepath.Clean(dir), "."+base+".wordrow-*")
+	if os.IsPermission(err) {
+		return f.rewriteInPlace(write)
+	} else if err != nil {
+		return err
+	}
+
+	keep := false
+	defer func() {
+		if err != nil || !keep {
+			temp.Close()
+			os.Remove(temp.Name())
+		}
+	}()
+
+	if keep, err = write(temp); err != nil || !keep {
+		return err
+	}
+
+	if err = preserveMetadata(temp, path, info); err != nil {
+		return err
+	}
+
+	if f.sync {
+		if err = temp.Sync(); err != nil {
+			return err
+		}
+	}
+
+	written, err := temp.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err = temp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(temp.Name(), path); err != nil {
+		return err
+	}
+
+	f.written = written
+
+	if f.sync {
+		err = syncDir(dir)
+	}
+
+	return err
+}
+
+// Replace the contents of the File by the data that `write` writes, like
+// Rewrite, but by overwriting the File in place. The data is first written to a
+// temporary file in the default directory for temporary files, so that `write`
+// can read the File while writing.
+func (f *File) rewriteInPlace(write func(w io.Writer) (bool, error)) error {
+	temp, err := ioutil.TempFile("", "wordrow-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		temp.Close()
+		os.Remove(temp.Name())
+	}()
+
+	if keep, err := write(temp); err != nil || !keep {
+		return err
+	}
+
+	if _, err = temp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err = f.handle.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err = f.handle.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f.handle, temp); err != nil {
+		return err
+	}
+
+	if f.sync {
+		if err = f.handle.Sync(); err != nil {
+			return err
+		}
+	}
+
+	f.written, err = f.handle.Stat()
+	return err
+}
+
+// Preserve the metadata of the file at `path`, described by `info`, on the file
+// `temp`. Ownership and extended attributes are preserved where possible,
+// failing to preserve those is not an error.
+func preserveMetadata(temp *os.File, path string, info os.FileInfo) error {
+	preserveOwner(temp, info)
+	preserveXattrs(temp, path)
+
+	// The permissions are set last because changing the owner of a file may
+	// clear some of its permissions.
+	return temp.Chmod(info.Mode() & preservedMode)
 }
@@ -0,0 +1,15 @@
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package fs
+
+import "os"
+
+// Preserve the owner of a file, described by `info`, on the file `temp`. This
+// is not supported on this platform.
+func preserveOwner(temp *os.File, info os.FileInfo) {}
+
+// Sync the directory `dir` to the storage device so that the entries in the
+// directory are persisted. This is not supported on this platform.
+func syncDir(dir string) error {
+	return nil
+}
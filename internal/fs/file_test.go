@@ -0,0 +1,307 @@
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+)
+
+// Create a temporary directory with a file named `name` containing `content`.
+// It returns the path of the file and a function to remove the directory.
+func createTempFile(t *testing.T, name, content string) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	cleanup := func() { os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		cleanup()
+		t.Fatalf("Could not create a temporary file (%s)", err)
+	}
+
+	return path, cleanup
+}
+
+// Open the file at `path` and write `data` to it using File.Write.
+func writeWithFile(t *testing.T, path string, flag Flag, data string) {
+	t.Helper()
+
+	file, err := OpenFile(path, flag)
+	if err != nil {
+		t.Fatalf("Could not open the file (%s)", err)
+	}
+
+	defer file.Close()
+
+	n, err := file.Write([]byte(data))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if n != len(data) {
+		t.Errorf("Unexpected number of bytes written (got %d)", n)
+	}
+}
+
+// Check that the content of the file at `path` equals `expected`.
+func checkContent(t *testing.T, path, expected string) {
+	t.Helper()
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read the file (%s)", err)
+	}
+
+	if string(content) != expected {
+		t.Errorf("Unexpected content (got '%s')", content)
+	}
+}
+
+func TestFileWrite(t *testing.T) {
+	t.Run("Replaces the content", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		writeWithFile(t, path, OReadWrite, "Hey")
+		checkContent(t, path, "Hey")
+	})
+	t.Run("Syncs the content", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		writeWithFile(t, path, OReadWriteSync, "Hey planet!")
+		checkContent(t, path, "Hey planet!")
+	})
+	t.Run("Leaves no temporary files", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		writeWithFile(t, path, OReadWrite, "Hey")
+
+		entries, err := ioutil.ReadDir(filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("Could not read the directory (%s)", err)
+		}
+
+		if len(entries) != 1 {
+			t.Errorf("Unexpected number of files in the directory (got %d)", len(entries))
+		}
+	})
+	t.Run("Preserves permissions", func(t *testing.T) {
+		if runtime.GOOS == windows {
+			t.Skip("Permissions are not supported on Windows")
+		}
+
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		if err := os.Chmod(path, 0640); err != nil {
+			t.Fatalf("Could not change permissions (%s)", err)
+		}
+
+		writeWithFile(t, path, OReadWrite, "Hey")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("Unexpected permissions (got %s)", info.Mode())
+		}
+	})
+	t.Run("Preserves symbolic links", func(t *testing.T) {
+		if runtime.GOOS == windows {
+			t.Skip("Symbolic links may not be supported on Windows")
+		}
+
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		link := filepath.Join(filepath.Dir(path), "link.txt")
+		if err := os.Symlink(path, link); err != nil {
+			t.Fatalf("Could not create a symbolic link (%s)", err)
+		}
+
+		writeWithFile(t, link, OReadWrite, "Hey")
+
+		info, err := os.Lstat(link)
+		if err != nil {
+			t.Fatalf("Could not stat the link (%s)", err)
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			t.Error("Expected the link to still be a symbolic link")
+		}
+
+		checkContent(t, path, "Hey")
+	})
+	t.Run("Read-only directory", func(t *testing.T) {
+		if runtime.GOOS == windows || os.Geteuid() == 0 {
+			t.Skip("Directory permissions are not enforced")
+		}
+
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		dir := filepath.Dir(path)
+		if err := os.Chmod(dir, 0555); err != nil {
+			t.Fatalf("Could not change permissions (%s)", err)
+		}
+
+		defer os.Chmod(dir, 0755)
+
+		writeWithFile(t, path, OReadWriteSync, "Hey")
+		checkContent(t, path, "Hey")
+	})
+	t.Run("Writing error", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		file, err := OpenFile(path, OReadWrite)
+		if err != nil {
+			t.Fatalf("Could not open the file (%s)", err)
+		}
+
+		defer file.Close()
+
+		if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+			t.Fatalf("Could not remove the directory (%s)", err)
+		}
+
+		if _, err := file.Write([]byte("Hey")); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
+
+func TestFileRewrite(t *testing.T) {
+	t.Run("Replaces the content while reading", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		file, err := OpenFile(path, OReadWrite)
+		if err != nil {
+			t.Fatalf("Could not open the file (%s)", err)
+		}
+
+		defer file.Close()
+
+		err = file.Rewrite(func(w io.Writer) (bool, error) {
+			_, err := io.Copy(w, io.MultiReader(file, stringsx.NewReader(" Bye!")))
+			return true, err
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		checkContent(t, path, "Hello world! Bye!")
+	})
+	t.Run("Leaves the content", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		file, err := OpenFile(path, OReadWrite)
+		if err != nil {
+			t.Fatalf("Could not open the file (%s)", err)
+		}
+
+		defer file.Close()
+
+		err = file.Rewrite(func(w io.Writer) (bool, error) {
+			_, err := w.Write([]byte("Hey"))
+			return false, err
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		checkContent(t, path, "Hello world!")
+
+		entries, err := ioutil.ReadDir(filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("Could not read the directory (%s)", err)
+		}
+
+		if len(entries) != 1 {
+			t.Errorf("Unexpected number of files in the directory (got %d)", len(entries))
+		}
+	})
+	t.Run("Writing error", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+		defer cleanup()
+
+		file, err := OpenFile(path, OReadWrite)
+		if err != nil {
+			t.Fatalf("Could not open the file (%s)", err)
+		}
+
+		defer file.Close()
+
+		err = file.Rewrite(func(w io.Writer) (bool, error) {
+			return true, errors.New("Something went wrong")
+		})
+		if err == nil {
+			t.Error("Expected an error but got none")
+		}
+
+		checkContent(t, path, "Hello world!")
+	})
+}
+
+func TestFileSize(t *testing.T) {
+	path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+	defer cleanup()
+
+	file, err := OpenFile(path, OReadWrite)
+	if err != nil {
+		t.Fatalf("Could not open the file (%s)", err)
+	}
+
+	defer file.Close()
+
+	if size, err := file.Size(); err != nil || size != int64(len("Hello world!")) {
+		t.Errorf("Unexpected size (got %d, %v)", size, err)
+	}
+}
+
+func TestFileWritten(t *testing.T) {
+	path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+	defer cleanup()
+
+	file, err := OpenFile(path, OReadWrite)
+	if err != nil {
+		t.Fatalf("Could not open the file (%s)", err)
+	}
+
+	defer file.Close()
+
+	if file.Written() != nil {
+		t.Error("Expected no state before the file is written")
+	}
+
+	if _, err := file.Write([]byte("Hey")); err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Could not stat the file (%s)", err)
+	}
+
+	written := file.Written()
+	if written == nil || isModified(written, info) {
+		t.Errorf("Unexpected state after the file is written (got %v)", written)
+	}
+}
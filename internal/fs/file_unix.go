@@ -0,0 +1,30 @@
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Preserve the owner of a file, described by `info`, on the file `temp`. This
+// is done on a best-effort basis, as changing the owner of a file may not be
+// permitted.
+func preserveOwner(temp *os.File, info os.FileInfo) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		_ = temp.Chown(int(stat.Uid), int(stat.Gid))
+	}
+}
+
+// Sync the directory `dir` to the storage device so that the entries in the
+// directory are persisted.
+func syncDir(dir string) error {
+	handle, err := os.Open(filepath.Clean(dir))
+	if err != nil {
+		return err
+	}
+
+	defer handle.Close()
+	return handle.Sync()
+}
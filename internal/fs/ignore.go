@@ -0,0 +1,233 @@
+package fs
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+// The names of the files with ignore patterns, in increasing order of
+// precedence. Both use the syntax of .gitignore files.
+var ignoreFileNames = []string{".gitignore", ".wordrowignore"}
+
+// The ignorePattern type represents a single pattern of an ignore file.
+type ignorePattern struct {
+	// The elements of the glob of the pattern, see matchElements.
+	elements []string
+
+	// Flag indicating whether the pattern re-includes matching paths.
+	negate bool
+
+	// Flag indicating whether the pattern only matches directories.
+	dirOnly bool
+
+	// Flag indicating whether the pattern ends in "/**" and so only matches
+	// what is inside a directory, not the directory itself.
+	descendants bool
+}
+
+// Parse a single `line` of an ignore file. It returns false if the line does
+// not contain a valid pattern.
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	var p ignorePattern
+
+	line = stringsx.TrimSuffix(line, "\r")
+	for stringsx.HasSuffix(line, " ") && !stringsx.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	if line == "" || stringsx.HasPrefix(line, "#") {
+		return p, false
+	}
+
+	if stringsx.HasPrefix(line, `\#`) || stringsx.HasPrefix(line, `\!`) {
+		line = line[1:]
+	} else if stringsx.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+
+	if stringsx.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = stringsx.TrimRight(line, "/")
+	}
+
+	if stringsx.Contains(line, "/") {
+		p.elements = stringsx.Split(stringsx.TrimPrefix(line, "/"), "/")
+		p.descendants = p.elements[len(p.elements)-1] == "**"
+	} else {
+		p.elements = []string{"**", line}
+	}
+
+	for _, element := range p.elements {
+		if _, err := path.Match(element, ""); err != nil || element == "" {
+			return p, false
+		}
+	}
+
+	return p, true
+}
+
+// Check whether the pattern matches the slash separated path `rel`, relative to
+// the directory of the ignore file, of a file or, if `isDir`, a directory.
+func (p *ignorePattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	elements := stringsx.Split(rel, "/")
+	if p.descendants && matchElements(p.elements[:len(p.elements)-1], elements) {
+		return false
+	}
+
+	return matchElements(p.elements, elements)
+}
+
+// The ignoreRules type represents the patterns of one or more ignore files in
+// the same directory.
+type ignoreRules struct {
+	// The absolute path of the directory the patterns are relative to.
+	base string
+
+	// The patterns, in increasing order of precedence.
+	patterns []ignorePattern
+}
+
+// Read the patterns from the `reader` and add them to the rules.
+func (r *ignoreRules) read(reader io.Reader) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if p, ok := parseIgnorePattern(scanner.Text()); ok {
+			r.patterns = append(r.patterns, p)
+		}
+	}
+}
+
+// Read the patterns from the file at `filePath`, if it exists, and add them to
+// the rules.
+func (r *ignoreRules) readFile(filePath string) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+
+	defer file.Close()
+	r.read(file)
+}
+
+// Check whether the rules decide on the absolute path `p` of a file or, if
+// `isDir`, a directory. If so, the second return value is whether the path is
+// ignored.
+func (r *ignoreRules) match(p string, isDir bool) (decided, ignored bool) {
+	rel, err := filepath.Rel(r.base, p)
+	if err != nil || rel == "." || rel == ".." ||
+		stringsx.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false, false
+	}
+
+	rel = filepath.ToSlash(rel)
+	for i := len(r.patterns) - 1; i >= 0; i-- {
+		if r.patterns[i].match(rel, isDir) {
+			return true, !r.patterns[i].negate
+		}
+	}
+
+	return false, false
+}
+
+// The ignoreMatcher type decides whether files and directories are ignored
+// based on the ignore files in the directories being walked and the directories
+// above them, following the semantics of .gitignore files. Within a git
+// repository this includes the ignore files up to the root of the repository
+// and its "info/exclude" file, outside of one the ignore files up to the root of
+// the filesystem.
+type ignoreMatcher struct {
+	// The rules of all directories, in increasing order of precedence.
+	rules []ignoreRules
+
+	// The rules of every directory that was entered, by absolute path.
+	loaded map[string]ignoreRules
+}
+
+// Find the root of the git repository containing the absolute path `dir`. It
+// returns the empty string if `dir` is not in a git repository.
+func findRepositoryRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// Create an ignoreMatcher for walking the absolute path `dir`. The ignore files
+// in `dir` itself are not loaded, see ignoreMatcher.enter.
+func newIgnoreMatcher(dir string) *ignoreMatcher {
+	m := &ignoreMatcher{loaded: make(map[string]ignoreRules)}
+
+	root := findRepositoryRoot(dir)
+	if root != "" {
+		exclude := ignoreRules{base: root}
+		exclude.readFile(filepath.Join(root, ".git", "info", "exclude"))
+		m.rules = append(m.rules, exclude)
+	}
+
+	var parents []string
+	for parent := dir; parent != root; {
+		next := filepath.Dir(parent)
+		if next == parent {
+			break
+		}
+
+		parent = next
+		parents = append(parents, parent)
+	}
+
+	for i := len(parents) - 1; i >= 0; i-- {
+		m.enter(parents[i])
+	}
+
+	return m
+}
+
+// Load the ignore files in the absolute path `dir`, which is being entered.
+func (m *ignoreMatcher) enter(dir string) {
+	rules, ok := m.loaded[dir]
+	if !ok {
+		rules = ignoreRules{base: dir}
+		for _, name := range ignoreFileNames {
+			rules.readFile(filepath.Join(dir, name))
+		}
+
+		m.loaded[dir] = rules
+	}
+
+	m.rules = append(m.rules, rules)
+}
+
+// Unload the ignore files of the directory that was last entered.
+func (m *ignoreMatcher) leave() {
+	m.rules = m.rules[:len(m.rules)-1]
+}
+
+// Check whether the absolute path `p` of a file or, if `isDir`, a directory is
+// ignored.
+func (m *ignoreMatcher) ignored(p string, isDir bool) bool {
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if decided, ignored := m.rules[i].match(p, isDir); decided {
+			return ignored
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,202 @@
+package fs
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+func TestParseIgnorePattern(t *testing.T) {
+	t.Run("No pattern", func(t *testing.T) {
+		for _, line := range []string{"", "   ", "# comment", "!", "/", "["} {
+			if _, ok := parseIgnorePattern(line); ok {
+				t.Errorf("Expected no pattern for '%s'", line)
+			}
+		}
+	})
+	t.Run("Unanchored", func(t *testing.T) {
+		p, ok := parseIgnorePattern("*.log  ")
+		if !ok || len(p.elements) != 2 || p.elements[0] != "**" || p.elements[1] != "*.log" {
+			t.Errorf("Unexpected pattern (got %+v)", p)
+		}
+	})
+	t.Run("Anchored", func(t *testing.T) {
+		p, ok := parseIgnorePattern("/docs/*.md")
+		if !ok || len(p.elements) != 2 || p.elements[0] != "docs" {
+			t.Errorf("Unexpected pattern (got %+v)", p)
+		}
+	})
+	t.Run("Negated directory", func(t *testing.T) {
+		p, ok := parseIgnorePattern("!build/")
+		if !ok || !p.negate || !p.dirOnly || p.elements[1] != "build" {
+			t.Errorf("Unexpected pattern (got %+v)", p)
+		}
+	})
+	t.Run("Escaped", func(t *testing.T) {
+		p, ok := parseIgnorePattern(`\#foo\ `)
+		if !ok || p.negate || !p.match("#foo ", false) {
+			t.Errorf("Unexpected pattern (got %+v)", p)
+		}
+
+		p, ok = parseIgnorePattern(`\!foo`)
+		if !ok || p.negate || !p.match("!foo", false) {
+			t.Errorf("Unexpected pattern (got %+v)", p)
+		}
+	})
+}
+
+func TestIgnorePatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		match   bool
+	}{
+		{"*.log", "foo.log", false, true},
+		{"*.log", "a/b/foo.log", false, true},
+		{"foo/", "a/foo", true, true},
+		{"foo/", "a/foo", false, false},
+		{"/foo", "foo", false, true},
+		{"/foo", "a/foo", false, false},
+		{"a/*.md", "a/b.md", false, true},
+		{"a/*.md", "x/a/b.md", false, false},
+		{"a/**/b", "a/b", false, true},
+		{"a/**/b", "a/x/y/b", false, true},
+		{"**/b", "x/b", false, true},
+		{"a/**", "a/x/y", false, true},
+		{"a/**", "a", true, false},
+		{"/a/**", "a/x", false, true},
+	}
+
+	for _, tc := range cases {
+		p, _ := parseIgnorePattern(tc.pattern)
+		if match := p.match(tc.path, tc.isDir); match != tc.match {
+			t.Errorf("Unexpected result for '%s' on '%s' (got %t)", tc.pattern, tc.path, match)
+		}
+	}
+}
+
+func TestIgnoreRules(t *testing.T) {
+	base, err := filepath.Abs("project")
+	if err != nil {
+		t.Fatalf("Could not get an absolute path (%s)", err)
+	}
+
+	r := ignoreRules{base: base}
+	r.read(stringsx.NewReader("*.tmp\n!keep.tmp\n"))
+
+	t.Run("Ignored", func(t *testing.T) {
+		decided, ignored := r.match(filepath.Join(base, "a.tmp"), false)
+		if !decided || !ignored {
+			t.Error("Expected the file to be ignored")
+		}
+	})
+	t.Run("Negated", func(t *testing.T) {
+		decided, ignored := r.match(filepath.Join(base, "keep.tmp"), false)
+		if !decided || ignored {
+			t.Error("Expected the file to be re-included")
+		}
+	})
+	t.Run("Undecided", func(t *testing.T) {
+		if decided, _ := r.match(filepath.Join(base, "a.md"), false); decided {
+			t.Error("Expected the rules not to decide")
+		}
+	})
+	t.Run("Outside base", func(t *testing.T) {
+		if decided, _ := r.match(filepath.Join(base, "..", "a.tmp"), false); decided {
+			t.Error("Expected the rules not to decide outside the base")
+		}
+	})
+}
+
+func TestWalkDirIgnoreFiles(t *testing.T) {
+	dir, cleanup := createTempTree(t,
+		"a.md",
+		"top.md",
+		"x.log",
+		"b.tmp",
+		"keep.tmp",
+		"generated/g.md",
+		"docs/c.tmp",
+		"docs/d.md",
+		"docs/generated",
+		"docs/top.md",
+		"docs/vendor/v.md",
+		"docs/vendor/.keep.md",
+		".git/info/exclude",
+		".gitignore",
+		"docs/.wordrowignore",
+	)
+	defer cleanup()
+
+	ignoreFiles := map[string]string{
+		".git/info/exclude":   "*.log\n",
+		".gitignore":          "generated/\n*.tmp\n!keep.tmp\n/top.md\n.*ignore\n",
+		"docs/.wordrowignore": "vendor\n",
+	}
+	for name, content := range ignoreFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Could not create an ignore file (%s)", err)
+		}
+	}
+
+	t.Run("Repository", func(t *testing.T) {
+		paths, errs := WalkDir(dir, WalkOptions{})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths,
+			"a.md",
+			"docs/d.md",
+			"docs/generated",
+			"docs/top.md",
+			"keep.tmp",
+		)
+	})
+	t.Run("Subdirectory", func(t *testing.T) {
+		docs := filepath.Join(dir, "docs")
+		paths, _ := WalkDir(docs, WalkOptions{})
+		checkPaths(t, docs, paths, "d.md", "generated", "top.md")
+	})
+	t.Run("No ignore", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{NoIgnore: true, Extensions: []string{"md"}})
+		checkPaths(t, dir, paths,
+			"a.md",
+			"docs/d.md",
+			"docs/top.md",
+			"docs/vendor/.keep.md",
+			"docs/vendor/v.md",
+			"generated/g.md",
+			"top.md",
+		)
+	})
+}
+
+func TestWalkDirIgnoreFilesOutsideRepository(t *testing.T) {
+	dir, cleanup := createTempTree(t,
+		".wordrowignore",
+		"sub/a.md",
+		"sub/b.tmp",
+		"sub/foo/drop.md",
+		"sub/foo/keep.md",
+	)
+	defer cleanup()
+
+	ignoreFile := filepath.Join(dir, ".wordrowignore")
+	content := []byte("*.tmp\nsub/foo/**\n!sub/foo/keep.md\n")
+	if err := ioutil.WriteFile(ignoreFile, content, 0644); err != nil {
+		t.Fatalf("Could not create an ignore file (%s)", err)
+	}
+
+	sub := filepath.Join(dir, "sub")
+	paths, errs := WalkDir(sub, WalkOptions{})
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors (got %v)", errs)
+	}
+
+	checkPaths(t, sub, paths, "a.md", "foo/keep.md")
+}
@@ -21,5 +21,6 @@ func OpenFile(filePath string, flag Flag) (file *File, err error) {
 	return &File{
 		handle: osFile,
 		path:   filePath,
+		sync:   flag == OReadWriteSync,
 	}, err
 }
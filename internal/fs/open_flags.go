@@ -11,6 +11,12 @@ const (
 
 	// OReadWrite is a flag to open a file in READ and WRITE mode.
 	OReadWrite
+
+	// OReadWriteSync is a flag to open a file in READ and WRITE mode, where the
+	// new contents written by File.Write or File.Rewrite are synced to the
+	// storage device before they replace the file, and the directory of the file
+	// is synced after they replaced it.
+	OReadWriteSync
 )
 
 // String returns the flag as a string.
@@ -18,6 +24,7 @@ func (flag Flag) String() string {
 	names := []string{
 		"ReadOnly",
 		"ReadWrite",
+		"ReadWriteSync",
 	}
 
 	return names[flag]
@@ -28,7 +35,7 @@ func flagToFlag(flag Flag) (f int) {
 	switch flag {
 	case OReadOnly:
 		f = os.O_RDONLY
-	case OReadWrite:
+	case OReadWrite, OReadWriteSync:
 		f = os.O_RDWR
 	default:
 		panic("unknown flag")
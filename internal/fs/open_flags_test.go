@@ -15,6 +15,12 @@ func TestFlagToString(t *testing.T) {
 			t.Errorf("Unexpected string for OReadWrite (got '%s')", result)
 		}
 	})
+	t.Run("ReadWriteSync", func(t *testing.T) {
+		result := OReadWriteSync.String()
+		if result != "ReadWriteSync" {
+			t.Errorf("Unexpected string for OReadWriteSync (got '%s')", result)
+		}
+	})
 }
 
 func TestFlagToFlag(t *testing.T) {
@@ -33,6 +39,10 @@ func TestFlagToFlag(t *testing.T) {
 		defer checkForPanic(t)
 		flagToFlag(OReadWrite)
 	})
+	t.Run("ReadWriteSync", func(t *testing.T) {
+		defer checkForPanic(t)
+		flagToFlag(OReadWriteSync)
+	})
 	t.Run("ReadWrite", func(t *testing.T) {
 		defer func() {
 			if r := recover(); r == nil {
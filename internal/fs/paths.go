@@ -1,8 +1,10 @@
 package fs
 
 import (
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 
 	"github.com/ericcornelissen/wordrow/internal/errors"
 	"github.com/yargevad/filepathx"
@@ -16,22 +18,120 @@ func GetExt(path string) string {
 	return filepath.Ext(path)
 }
 
+// Get the directory of the glob `pattern` up to its first element containing a
+// special character, e.g. "docs" for "docs/**/*.md".
+func globBase(pattern string) string {
+	base := filepath.Dir(pattern)
+	for globExpr.MatchString(base) {
+		base = filepath.Dir(base)
+	}
+
+	return base
+}
+
+// Get the canonical form of the file path `path`. That is, the path without any
+// redundant elements such as "./" and "..", and, if `resolveSymlinks` is set,
+// with all symbolic links resolved. If a symbolic link cannot be resolved it is
+// kept as is.
+func canonicalize(path string, resolveSymlinks bool) string {
+	path = filepath.Clean(path)
+	if resolveSymlinks {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+	}
+
+	return path
+}
+
+// Sort the file `paths` and leave out any path that refers to the same file as
+// a path before it. If `onDuplicate` is not nil it is called for every path that
+// is left out, together with the path it duplicates.
+func dedupe(paths []string, onDuplicate func(path, original string)) []string {
+	sort.Strings(paths)
+
+	seen := make(map[string]string, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		key, err := filepath.Abs(path)
+		if err != nil {
+			key = path
+		}
+
+		if original, ok := seen[key]; ok {
+			if onDuplicate != nil {
+				onDuplicate(path, original)
+			}
+
+			continue
+		}
+
+		seen[key] = path
+		unique = append(unique, path)
+	}
+
+	return unique
+}
+
 // ResolveGlobs resolves any number of globs or file paths into distinct file
-// paths. The function returns an error for every invalid pattern.
+// paths, walking directories with the default options. The function returns an
+// error for every invalid pattern, see ResolveGlobsWith.
 func ResolveGlobs(patterns ...string) (paths []string, errs []error) {
+	return ResolveGlobsWith(WalkOptions{}, patterns...)
+}
+
+// ResolveGlobsWith resolves any number of globs or file paths into distinct file
+// paths. File paths are kept as is, except for paths of directories which are
+// walked using the `options`, see WalkDir. Directories matching a glob are left
+// out, and files matching a glob are only kept if they pass the filters of the
+// `options` and would be found by walking the directory the glob starts in. That
+// is, if they are not in a directory that is excluded by default and they are
+// not ignored.
+//
+// The resolved paths are canonicalized, see WalkOptions.ResolveSymlinks, and
+// returned in sorted order. If multiple paths refer to the same file only the
+// first is kept, which is not an error, see WalkOptions.OnDuplicate. The
+// function returns an error for every invalid pattern and every error that
+// occurs while walking a directory.
+func ResolveGlobsWith(
+	options WalkOptions,
+	patterns ...string,
+) (paths []string, errs []error) {
+	errs = options.validate()
 	for _, pattern := range patterns {
 		if !globExpr.MatchString(pattern) {
-			paths = append(paths, pattern)
+			if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+				found, walkErrs := WalkDir(pattern, options)
+				paths = append(paths, found...)
+				errs = append(errs, walkErrs...)
+			} else {
+				paths = append(paths, pattern)
+			}
+
 			continue
 		}
 
 		matches, err := filepathx.Glob(pattern)
 		if err != nil {
 			errs = append(errs, errors.Newf("Malformed pattern (%s)", pattern))
-		} else {
-			paths = append(paths, matches...)
+			continue
 		}
+
+		filter := newGlobFilter(&options, pattern)
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				continue
+			}
+
+			if options.keep(match) && !filter.skip(match) {
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	for i, path := range paths {
+		paths[i] = canonicalize(path, options.ResolveSymlinks)
 	}
 
-	return paths, errs
+	return dedupe(paths, options.OnDuplicate), errs
 }
@@ -1,6 +1,11 @@
 package fs
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
 
 func TestGetExt(t *testing.T) {
 	path := "foo.bar"
@@ -12,6 +17,23 @@ func TestGetExt(t *testing.T) {
 	}
 }
 
+func TestGlobBase(t *testing.T) {
+	cases := map[string]string{
+		"*.md":                 ".",
+		"**/*.md":              ".",
+		"docs/*.md":            "docs",
+		"docs/**/*.md":         "docs",
+		"docs/[a-z]*/api/*.md": "docs",
+	}
+
+	for pattern, expected := range cases {
+		pattern = filepath.FromSlash(pattern)
+		if base := globBase(pattern); base != filepath.FromSlash(expected) {
+			t.Errorf("Unexpected base for '%s' (got '%s')", pattern, base)
+		}
+	}
+}
+
 func TestResolveGlobsNoGlobs(t *testing.T) {
 	resolvedPaths, err := ResolveGlobs()
 
@@ -57,3 +79,87 @@ func TestResolveGlobsWithMalformedPattern(t *testing.T) {
 		t.Error("The error should be set for a malformed glob")
 	}
 }
+
+func TestResolveGlobsCanonicalizes(t *testing.T) {
+	resolvedPaths, _ := ResolveGlobs("./foo/../bar.txt", "foo//baz.txt")
+
+	if len(resolvedPaths) != 2 {
+		t.Fatalf("Resolving two distinct paths should return two paths (was %d)", len(resolvedPaths))
+	}
+
+	if resolvedPaths[0] != "bar.txt" || resolvedPaths[1] != filepath.Join("foo", "baz.txt") {
+		t.Errorf("The resolved paths should be canonical and sorted (was %q)", resolvedPaths)
+	}
+}
+
+func TestResolveGlobsDeduplicates(t *testing.T) {
+	dir, cleanup := createTempTree(t, "docs/README.md", "docs/guide.md")
+	defer cleanup()
+
+	var duplicates []string
+	options := WalkOptions{
+		OnDuplicate: func(path, original string) {
+			duplicates = append(duplicates, path)
+			if path != original {
+				t.Errorf("Unexpected original for '%s' (got '%s')", path, original)
+			}
+		},
+	}
+
+	readme := filepath.Join(dir, "docs", "README.md")
+	resolvedPaths, errs := ResolveGlobsWith(
+		options,
+		filepath.Join(dir, "docs", "*.md"),
+		readme,
+		filepath.Join(dir, "docs", ".", "README.md"),
+	)
+
+	checkPaths(t, dir, resolvedPaths, "docs/README.md", "docs/guide.md")
+
+	if len(errs) != 0 {
+		t.Errorf("Duplicates should not set the error (was %v)", errs)
+	}
+
+	if len(duplicates) != 2 {
+		t.Errorf("Every duplicate should be reported (got %q)", duplicates)
+	}
+}
+
+func TestResolveGlobsResolveSymlinks(t *testing.T) {
+	if runtime.GOOS == windows {
+		t.Skip("Symbolic links require elevated privileges on Windows")
+	}
+
+	dir, cleanup := createTempTree(t, "docs/README.md")
+	defer cleanup()
+
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatalf("Could not resolve the temporary directory (%s)", err)
+	}
+
+	link := filepath.Join(dir, "link.md")
+	if err := os.Symlink(filepath.Join("docs", "README.md"), link); err != nil {
+		t.Fatalf("Could not create a symbolic link (%s)", err)
+	}
+
+	readme := filepath.Join(dir, "docs", "README.md")
+
+	t.Run("Unresolved", func(t *testing.T) {
+		resolvedPaths, errs := ResolveGlobsWith(WalkOptions{}, readme, link)
+		if len(errs) != 0 {
+			t.Errorf("Distinct paths should not set the error (was %v)", errs)
+		}
+
+		checkPaths(t, dir, resolvedPaths, "docs/README.md", "link.md")
+	})
+	t.Run("Resolved", func(t *testing.T) {
+		options := WalkOptions{ResolveSymlinks: true}
+		resolvedPaths, errs := ResolveGlobsWith(options, link, readme)
+		if len(errs) != 0 {
+			t.Errorf("A duplicate should not set the error (was %v)", errs)
+		}
+
+		checkPaths(t, dir, resolvedPaths, "docs/README.md")
+	})
+}
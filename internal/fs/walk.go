@@ -0,0 +1,402 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/errors"
+)
+
+// SymlinkPolicy is the policy for symbolic links found while walking a
+// directory.
+type SymlinkPolicy int
+
+// The policies for symbolic links found while walking a directory.
+const (
+	// SymlinksSkip is the policy to leave out symbolic links.
+	SymlinksSkip SymlinkPolicy = iota
+
+	// SymlinksFollow is the policy to follow symbolic links to files and
+	// directories. Links to a directory that is already being walked are left out
+	// to avoid cycles.
+	SymlinksFollow
+
+	// SymlinksError is the policy to leave out symbolic links and return an error
+	// for each of them.
+	SymlinksError
+)
+
+// The names of directories, other than hidden directories, that are not walked
+// by default.
+var defaultExcludedDirs = map[string]bool{
+	"node_modules": true,
+}
+
+// WalkOptions configures how directories are walked and which of the files
+// found by walking a directory or resolving a glob are kept. The zero value
+// walks directories without a depth limit, keeps every file, leaves out
+// symbolic links, does not walk hidden directories (e.g. ".git") or
+// "node_modules" directories, and leaves out files and directories ignored by
+// .gitignore or .wordrowignore files.
+//
+// The Include and Exclude globs are matched against file paths as found, using
+// forward slashes. A glob without a slash matches if any element of the path
+// matches it, e.g. "vendor" matches "docs/vendor/foo.md". Otherwise the glob
+// must match the path, or one of the directories in it, element by element
+// where "**" matches any number of elements, e.g. "docs/**/*.md".
+type WalkOptions struct {
+	// Globs of which a file must match at least one to be kept, if any.
+	Include []string
+
+	// Globs of files and directories to leave out.
+	Exclude []string
+
+	// Extensions of which a file must have one to be kept, if any. The leading
+	// dot is optional.
+	Extensions []string
+
+	// The maximum depth to walk a directory to, where the files directly inside
+	// the directory are at depth 1. There is no limit if it is 0.
+	MaxDepth int
+
+	// The policy for symbolic links found while walking a directory.
+	Symlinks SymlinkPolicy
+
+	// Flag indicating whether hidden and "node_modules" directories are walked.
+	NoDefaultExcludes bool
+
+	// Flag indicating whether .gitignore and .wordrowignore files are disregarded
+	// when walking a directory.
+	NoIgnore bool
+
+	// Flag indicating whether symbolic links in resolved paths are replaced by
+	// their targets, so that a file is found only once even if it is reachable
+	// through different links.
+	ResolveSymlinks bool
+
+	// Function called for every resolved path that is left out because it refers
+	// to the same file as the `original` path, if any.
+	OnDuplicate func(path, original string)
+}
+
+// Check whether the `elements` of a path match the elements `patterns` of a
+// glob, where "**" matches any number of elements.
+func matchElements(patterns, elements []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(elements); i++ {
+				if matchElements(patterns[1:], elements[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(elements) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(patterns[0], elements[0]); !ok {
+			return false
+		}
+
+		patterns, elements = patterns[1:], elements[1:]
+	}
+
+	return len(elements) == 0
+}
+
+// Check whether the file path `p` matches the glob `pattern`, see WalkOptions.
+func matchGlob(pattern, p string) bool {
+	pattern = path.Clean(filepath.ToSlash(pattern))
+	elements := stringsx.Split(path.Clean(filepath.ToSlash(p)), "/")
+
+	if !stringsx.Contains(pattern, "/") {
+		for _, element := range elements {
+			if ok, _ := path.Match(pattern, element); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	patterns := stringsx.Split(pattern, "/")
+	for n := len(elements); n > 0; n-- {
+		if matchElements(patterns, elements[:n]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check whether the file path `p` matches any of the `patterns`.
+func matchAnyGlob(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check whether the file path `p` has any of the `extensions`.
+func hasExtension(extensions []string, p string) bool {
+	ext := filepath.Ext(p)
+	for _, extension := range extensions {
+		if !stringsx.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+
+		if stringsx.EqualFold(ext, extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check that the Include and Exclude globs of the options are well-formed. The
+// function returns an error for every malformed glob.
+func (o *WalkOptions) validate() (errs []error) {
+	for _, pattern := range append(o.Include, o.Exclude...) {
+		if _, err := path.Match(filepath.ToSlash(pattern), ""); err != nil {
+			errs = append(errs, errors.Newf("Malformed pattern (%s)", pattern))
+		}
+	}
+
+	return errs
+}
+
+// Check whether the file at `p` should be kept according to the options.
+func (o *WalkOptions) keep(p string) bool {
+	if matchAnyGlob(o.Exclude, p) {
+		return false
+	}
+
+	if len(o.Include) > 0 && !matchAnyGlob(o.Include, p) {
+		return false
+	}
+
+	if len(o.Extensions) > 0 && !hasExtension(o.Extensions, p) {
+		return false
+	}
+
+	return true
+}
+
+// Check whether the directory named `name` is not walked by default according
+// to the options.
+func (o *WalkOptions) excludedByDefault(name string) bool {
+	if o.NoDefaultExcludes {
+		return false
+	}
+
+	return stringsx.HasPrefix(name, ".") || defaultExcludedDirs[name]
+}
+
+// Check whether the directory named `name` at `p` should be walked according
+// to the options.
+func (o *WalkOptions) walkable(name, p string) bool {
+	return !o.excludedByDefault(name) && !matchAnyGlob(o.Exclude, p)
+}
+
+// The walker type represents a single walk of a directory.
+type walker struct {
+	// The options of the walk.
+	options *WalkOptions
+
+	// The matcher for ignored files and directories, nil if there is none.
+	ignores *ignoreMatcher
+
+	// The directories that are being walked, from the root down.
+	ancestors []os.FileInfo
+
+	// The files that were found.
+	paths []string
+
+	// The errors that occurred.
+	errs []error
+}
+
+// Check whether the directory described by `info` is already being walked.
+func (w *walker) isAncestor(info os.FileInfo) bool {
+	for _, ancestor := range w.ancestors {
+		if os.SameFile(ancestor, info) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolve the symbolic link at `p` according to the symbolic link policy. It
+// returns the description of the target, or nil if the link should be left
+// out.
+func (w *walker) resolveSymlink(p string) os.FileInfo {
+	switch w.options.Symlinks {
+	case SymlinksFollow:
+		info, err := os.Stat(p)
+		if err != nil {
+			w.errs = append(w.errs, err)
+			return nil
+		}
+
+		return info
+	case SymlinksError:
+		w.errs = append(w.errs, errors.Newf("Symbolic link not allowed (%s)", p))
+	}
+
+	return nil
+}
+
+// Check whether the file or directory at the absolute path `p`, described by
+// `info`, is ignored.
+func (w *walker) isIgnored(p string, info os.FileInfo) bool {
+	return w.ignores != nil && w.ignores.ignored(p, info.IsDir())
+}
+
+// Walk the directory `dir`, at the absolute path `abs` and described by `info`,
+// which is at `depth`.
+func (w *walker) walk(dir, abs string, info os.FileInfo, depth int) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		w.errs = append(w.errs, err)
+		return
+	}
+
+	w.ancestors = append(w.ancestors, info)
+	defer func() { w.ancestors = w.ancestors[:len(w.ancestors)-1] }()
+
+	if w.ignores != nil {
+		w.ignores.enter(abs)
+		defer w.ignores.leave()
+	}
+
+	for _, info := range infos {
+		p := filepath.Join(dir, info.Name())
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info = w.resolveSymlink(p); info == nil {
+				continue
+			}
+		}
+
+		absP := filepath.Join(abs, info.Name())
+		if w.isIgnored(absP, info) {
+			continue
+		}
+
+		if info.IsDir() {
+			maxDepth := w.options.MaxDepth
+			if (maxDepth == 0 || depth < maxDepth) &&
+				w.options.walkable(info.Name(), p) &&
+				!w.isAncestor(info) {
+				w.walk(p, absP, info, depth+1)
+			}
+		} else if info.Mode().IsRegular() && w.options.keep(p) {
+			w.paths = append(w.paths, p)
+		}
+	}
+}
+
+// WalkDir returns the paths of the files in the directory `dir` and, within
+// the limits of the `options`, its subdirectories, in lexical order. Any error
+// that occurs is returned after the directory has been walked.
+func WalkDir(dir string, options WalkOptions) (paths []string, errs []error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	w := walker{options: &options}
+	if !options.NoIgnore {
+		w.ignores = newIgnoreMatcher(abs)
+	}
+
+	w.walk(dir, abs, info, 1)
+	return w.paths, w.errs
+}
+
+// The globFilter type leaves out the files matching a glob that would not be
+// found by walking the directory the glob starts in, see globBase, because they
+// are in a directory that is not walked by default or because they are ignored.
+type globFilter struct {
+	// The options of the walk.
+	options *WalkOptions
+
+	// The directory the glob starts in, and its absolute path.
+	base, abs string
+
+	// The matcher for ignored files and directories, nil if there is none.
+	ignores *ignoreMatcher
+}
+
+// Create a globFilter for the files matching the glob `pattern`.
+func newGlobFilter(options *WalkOptions, pattern string) *globFilter {
+	f := &globFilter{options: options, base: globBase(pattern)}
+
+	abs, err := filepath.Abs(f.base)
+	if err != nil {
+		return f
+	}
+
+	f.abs = abs
+	if !options.NoIgnore {
+		f.ignores = newIgnoreMatcher(abs)
+		f.ignores.enter(abs)
+	}
+
+	return f
+}
+
+// Check whether the file at `p`, which matches the glob, should be left out.
+func (f *globFilter) skip(p string) bool {
+	rel, err := filepath.Rel(f.base, p)
+	if err != nil || f.abs == "" || rel == ".." ||
+		stringsx.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	names := stringsx.Split(rel, string(filepath.Separator))
+	last := len(names) - 1
+
+	skip, entered, abs := false, 0, f.abs
+	for i, name := range names {
+		abs = filepath.Join(abs, name)
+		if i < last && f.options.excludedByDefault(name) {
+			skip = true
+			break
+		}
+
+		if f.ignores == nil {
+			continue
+		}
+
+		if f.ignores.ignored(abs, i < last) {
+			skip = true
+			break
+		}
+
+		if i < last {
+			f.ignores.enter(abs)
+			entered++
+		}
+	}
+
+	for ; entered > 0; entered-- {
+		f.ignores.leave()
+	}
+
+	return skip
+}
@@ -0,0 +1,301 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Create a temporary directory containing a file for every path in `files`. It
+// returns the path of the directory and a function to remove it.
+func createTempTree(t *testing.T, files ...string) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	cleanup := func() { os.RemoveAll(dir) }
+
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			cleanup()
+			t.Fatalf("Could not create a temporary directory (%s)", err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(file), 0644); err != nil {
+			cleanup()
+			t.Fatalf("Could not create a temporary file (%s)", err)
+		}
+	}
+
+	return dir, cleanup
+}
+
+// Check that the `paths` equal the `expected` paths relative to `dir`.
+func checkPaths(t *testing.T, dir string, paths []string, expected ...string) {
+	t.Helper()
+
+	if len(paths) != len(expected) {
+		t.Fatalf("Unexpected paths (got %q)", paths)
+	}
+
+	for i, path := range expected {
+		if paths[i] != filepath.Join(dir, filepath.FromSlash(path)) {
+			t.Errorf("Unexpected path at %d (got '%s')", i, paths[i])
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*.md", "foo.md", true},
+		{"*.md", "docs/foo.md", true},
+		{"*.md", "docs/foo.txt", false},
+		{"vendor", "docs/vendor/foo.md", true},
+		{"vendor", "docs/vendors/foo.md", false},
+		{"docs/*.md", "docs/foo.md", true},
+		{"docs/*.md", "docs/sub/foo.md", false},
+		{"docs/**/*.md", "docs/foo.md", true},
+		{"docs/**/*.md", "docs/sub/foo.md", true},
+		{"docs/**/*.md", "other/foo.md", false},
+		{"docs/sub", "docs/sub/foo.md", true},
+		{"./docs/sub", "docs/sub/foo.md", true},
+		{"docs/sub", "docs/subway/foo.md", false},
+	}
+
+	for _, tc := range cases {
+		if match := matchGlob(tc.pattern, tc.path); match != tc.match {
+			t.Errorf("Unexpected result for '%s' on '%s' (got %t)", tc.pattern, tc.path, match)
+		}
+	}
+}
+
+func TestHasExtension(t *testing.T) {
+	if !hasExtension([]string{"md"}, "foo.md") {
+		t.Error("Expected an extension without a dot to match")
+	}
+
+	if !hasExtension([]string{".txt", ".md"}, "foo.MD") {
+		t.Error("Expected extensions to match regardless of case")
+	}
+
+	if hasExtension([]string{"md"}, "foo.txt") {
+		t.Error("Expected a different extension not to match")
+	}
+}
+
+func TestWalkDir(t *testing.T) {
+	dir, cleanup := createTempTree(t,
+		"a.md",
+		"b.txt",
+		"sub/c.md",
+		"sub/deep/d.md",
+		"vendor/v.md",
+		".git/config",
+		"node_modules/x.md",
+	)
+	defer cleanup()
+
+	t.Run("Default", func(t *testing.T) {
+		paths, errs := WalkDir(dir, WalkOptions{})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "b.txt", "sub/c.md", "sub/deep/d.md", "vendor/v.md")
+	})
+	t.Run("No default excludes", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{NoDefaultExcludes: true})
+		checkPaths(t, dir, paths,
+			".git/config",
+			"a.md",
+			"b.txt",
+			"node_modules/x.md",
+			"sub/c.md",
+			"sub/deep/d.md",
+			"vendor/v.md",
+		)
+	})
+	t.Run("Maximum depth", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{MaxDepth: 1})
+		checkPaths(t, dir, paths, "a.md", "b.txt")
+
+		paths, _ = WalkDir(dir, WalkOptions{MaxDepth: 2})
+		checkPaths(t, dir, paths, "a.md", "b.txt", "sub/c.md", "vendor/v.md")
+	})
+	t.Run("Exclude", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{Exclude: []string{"vendor", "*.txt"}})
+		checkPaths(t, dir, paths, "a.md", "sub/c.md", "sub/deep/d.md")
+	})
+	t.Run("Include", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{Include: []string{"sub"}})
+		checkPaths(t, dir, paths, "sub/c.md", "sub/deep/d.md")
+	})
+	t.Run("Extensions", func(t *testing.T) {
+		paths, _ := WalkDir(dir, WalkOptions{Extensions: []string{"txt"}})
+		checkPaths(t, dir, paths, "b.txt")
+	})
+	t.Run("Missing directory", func(t *testing.T) {
+		_, errs := WalkDir(filepath.Join(dir, "missing"), WalkOptions{})
+		if len(errs) != 1 {
+			t.Errorf("Unexpected errors (got %v)", errs)
+		}
+	})
+}
+
+func TestWalkDirSymlinks(t *testing.T) {
+	if runtime.GOOS == windows {
+		t.Skip("Symbolic links require elevated privileges on Windows")
+	}
+
+	dir, cleanup := createTempTree(t, "a.md", "sub/c.md")
+	defer cleanup()
+
+	links := map[string]string{
+		"link":     "sub",
+		"linkfile": "a.md",
+		"sub/loop": "..",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Fatalf("Could not create a symbolic link (%s)", err)
+		}
+	}
+
+	t.Run("Skip", func(t *testing.T) {
+		paths, errs := WalkDir(dir, WalkOptions{Symlinks: SymlinksSkip})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "sub/c.md")
+	})
+	t.Run("Follow", func(t *testing.T) {
+		paths, errs := WalkDir(dir, WalkOptions{Symlinks: SymlinksFollow})
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "link/c.md", "linkfile", "sub/c.md")
+	})
+	t.Run("Error", func(t *testing.T) {
+		paths, errs := WalkDir(dir, WalkOptions{Symlinks: SymlinksError})
+		if len(errs) != len(links) {
+			t.Errorf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "sub/c.md")
+	})
+}
+
+func TestResolveGlobsWith(t *testing.T) {
+	dir, cleanup := createTempTree(t, "a.md", "b.txt", "sub/c.md")
+	defer cleanup()
+
+	t.Run("Directory", func(t *testing.T) {
+		paths, errs := ResolveGlobsWith(WalkOptions{}, dir)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "b.txt", "sub/c.md")
+	})
+	t.Run("Glob", func(t *testing.T) {
+		paths, _ := ResolveGlobsWith(WalkOptions{}, filepath.Join(dir, "*"))
+		checkPaths(t, dir, paths, "a.md", "b.txt")
+	})
+	t.Run("Filtered glob", func(t *testing.T) {
+		options := WalkOptions{Extensions: []string{"md"}}
+		paths, _ := ResolveGlobsWith(options, filepath.Join(dir, "*"))
+		checkPaths(t, dir, paths, "a.md")
+	})
+	t.Run("Unfiltered file", func(t *testing.T) {
+		options := WalkOptions{Extensions: []string{"md"}}
+		paths, _ := ResolveGlobsWith(options, filepath.Join(dir, "b.txt"))
+		checkPaths(t, dir, paths, "b.txt")
+	})
+	t.Run("Malformed filter", func(t *testing.T) {
+		_, errs := ResolveGlobsWith(WalkOptions{Exclude: []string{"["}}, dir)
+		if len(errs) != 1 {
+			t.Errorf("Unexpected errors (got %v)", errs)
+		}
+	})
+}
+
+func TestResolveGlobsWithIgnores(t *testing.T) {
+	dir, cleanup := createTempTree(t,
+		"a.md",
+		"#b.md",
+		"!c.md",
+		".hidden/d.md",
+		"node_modules/e.md",
+		"generated/f.md",
+		"docs/g.md",
+		"docs/h.md",
+		".wordrowignore",
+		"docs/.wordrowignore",
+	)
+	defer cleanup()
+
+	ignoreFiles := map[string]string{
+		".wordrowignore":      "generated/\n\\#b.md\n\\!c.md\n",
+		"docs/.wordrowignore": "h.md\n",
+	}
+	for name, content := range ignoreFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Could not create an ignore file (%s)", err)
+		}
+	}
+
+	pattern := filepath.Join(dir, "**", "*.md")
+
+	t.Run("Glob", func(t *testing.T) {
+		paths, errs := ResolveGlobsWith(WalkOptions{}, pattern)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors (got %v)", errs)
+		}
+
+		checkPaths(t, dir, paths, "a.md", "docs/g.md")
+	})
+	t.Run("Glob in directory", func(t *testing.T) {
+		docs := filepath.Join(dir, "docs")
+		paths, _ := ResolveGlobsWith(WalkOptions{}, filepath.Join(docs, "*.md"))
+		checkPaths(t, docs, paths, "g.md")
+	})
+	t.Run("No default excludes", func(t *testing.T) {
+		options := WalkOptions{NoDefaultExcludes: true}
+		paths, _ := ResolveGlobsWith(options, pattern)
+		checkPaths(t, dir, paths,
+			".hidden/d.md",
+			"a.md",
+			"docs/g.md",
+			"node_modules/e.md",
+		)
+	})
+	t.Run("No ignore", func(t *testing.T) {
+		paths, _ := ResolveGlobsWith(WalkOptions{NoIgnore: true}, pattern)
+		checkPaths(t, dir, paths,
+			"!c.md",
+			"#b.md",
+			"a.md",
+			"docs/g.md",
+			"docs/h.md",
+			"generated/f.md",
+		)
+	})
+	t.Run("File", func(t *testing.T) {
+		file := filepath.Join(dir, "docs", "h.md")
+		paths, _ := ResolveGlobsWith(WalkOptions{}, file)
+		checkPaths(t, dir, paths, "docs/h.md")
+	})
+}
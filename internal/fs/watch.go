@@ -0,0 +1,174 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+// Watcher watches the files matching a list of globs or file paths for changes.
+// It polls the file system at a fixed interval. Where available, it also uses
+// the notification mechanism of the operating system (inotify on Linux) to
+// notice changes before the next poll.
+type Watcher struct {
+	// The globs or file paths to watch.
+	patterns []string
+
+	// The file paths to watch as is, see AddFiles.
+	files []string
+
+	// The options for walking directories and filtering files.
+	options WalkOptions
+
+	// The interval at which the file system is polled.
+	interval time.Duration
+
+	// The files matching the patterns as of the last poll, in sorted order.
+	paths []string
+
+	// The state of every file as of the last poll.
+	states map[string]os.FileInfo
+
+	// The notifier of the Watcher, which may be nil.
+	notifier *notifier
+}
+
+// Check whether the file described by `current` was modified since the file was
+// described by `previous`. Replacing a file counts as modifying it.
+func isModified(previous, current os.FileInfo) bool {
+	return !current.ModTime().Equal(previous.ModTime()) ||
+		current.Size() != previous.Size() ||
+		!os.SameFile(previous, current)
+}
+
+// Get the directory in which files matching `pattern` may be created, i.e. the
+// leading part of `pattern` that contains no glob characters.
+func getBaseDir(pattern string) string {
+	if !globExpr.MatchString(pattern) {
+		return filepath.Dir(pattern)
+	}
+
+	var base []string
+	for _, part := range stringsx.Split(filepath.ToSlash(pattern), "/") {
+		if globExpr.MatchString(part) {
+			break
+		}
+
+		base = append(base, part)
+	}
+
+	if len(base) == 0 {
+		return "."
+	}
+
+	return filepath.FromSlash(stringsx.Join(base, "/") + "/")
+}
+
+// NewWatcher creates a Watcher for the files matching the `patterns`, resolved
+// with the `options`, that polls the file system every `interval`. A Watcher
+// should be closed when it is no longer used.
+func NewWatcher(
+	interval time.Duration,
+	options WalkOptions,
+	patterns ...string,
+) *Watcher {
+	return &Watcher{
+		patterns: patterns,
+		options:  options,
+		interval: interval,
+		states:   make(map[string]os.FileInfo),
+		notifier: newNotifier(),
+	}
+}
+
+// Poll returns the files matching the patterns of the Watcher that were created
+// or modified since the previous poll, in sorted order. The first
+// poll returns all files. The function returns an error for every invalid
+// pattern, see ResolveGlobsWith.
+func (w *Watcher) Poll() (changed []string, errs []error) {
+	paths, errs := ResolveGlobsWith(w.options, w.patterns...)
+	for _, path := range w.files {
+		paths = append(paths, filepath.Clean(path))
+	}
+
+	paths = dedupe(paths, nil)
+	for _, pattern := range w.patterns {
+		w.notifier.watch(getBaseDir(pattern))
+	}
+
+	seen := make(map[string]bool, len(paths))
+	w.paths = w.paths[:0]
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		seen[path] = true
+		w.paths = append(w.paths, path)
+		w.notifier.watch(filepath.Dir(path))
+
+		if previous, ok := w.states[path]; !ok || isModified(previous, info) {
+			changed = append(changed, path)
+		}
+
+		w.states[path] = info
+	}
+
+	for path := range w.states {
+		if !seen[path] {
+			delete(w.states, path)
+		}
+	}
+
+	return changed, errs
+}
+
+// AddFiles adds the files at `paths` to the files watched by the Watcher. Unlike
+// its patterns, the paths are watched as is. That is, they are not resolved as
+// globs nor filtered using the options of the Watcher.
+func (w *Watcher) AddFiles(paths ...string) {
+	w.files = append(w.files, paths...)
+}
+
+// Files returns all files matching the patterns of the Watcher as of the last
+// poll, in sorted order.
+func (w *Watcher) Files() []string {
+	return append([]string(nil), w.paths...)
+}
+
+// Ignore records that the file at `path` was written by the program itself,
+// resulting in the state `written`, see File.Written. The next poll returns the
+// file only if it was modified after it was written, so changes made by the
+// program itself are ignored whereas later changes are not. Nothing is recorded
+// if `written` is nil.
+func (w *Watcher) Ignore(path string, written os.FileInfo) {
+	if _, ok := w.states[path]; ok && written != nil {
+		w.states[path] = written
+	}
+}
+
+// Wait blocks until it is time to poll again. That is, until the interval of
+// the Watcher has passed or, if possible, until a change is noticed. It returns
+// false, without waiting, if `done` is closed.
+func (w *Watcher) Wait(done <-chan struct{}) bool {
+	timer := time.NewTimer(w.interval)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+	case <-w.notifier.wakeups():
+	}
+
+	return true
+}
+
+// Close stops the Watcher from using the notification mechanism of the
+// operating system.
+func (w *Watcher) Close() error {
+	return w.notifier.close()
+}
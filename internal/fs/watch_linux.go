@@ -0,0 +1,160 @@
+// +build linux
+
+package fs
+
+import (
+	"sync"
+	"syscall"
+)
+
+// The inotify events on a watched directory that wake up a Watcher.
+const notifyMask = syscall.IN_CREATE |
+	syscall.IN_CLOSE_WRITE |
+	syscall.IN_MODIFY |
+	syscall.IN_MOVED_TO |
+	syscall.IN_MOVED_FROM |
+	syscall.IN_DELETE
+
+// The notifier type notifies a Watcher of changes in the directories it watches
+// using inotify. A nil notifier never notifies.
+type notifier struct {
+	// The inotify instance.
+	fd int
+
+	// The epoll instance used to wait for events on `fd` and `pipe`.
+	epfd int
+
+	// The pipe used to stop waiting for events.
+	pipe [2]int
+
+	// The directories that are watched.
+	dirs map[string]bool
+
+	// The channel on which changes are signalled.
+	wake chan struct{}
+
+	// Ensures the notifier is closed only once.
+	once sync.Once
+}
+
+// Create a new notifier. If inotify is not available nil is returned, in which
+// case a Watcher only polls.
+func newNotifier() *notifier {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil
+	}
+
+	n := &notifier{
+		fd:   fd,
+		dirs: make(map[string]bool),
+		wake: make(chan struct{}, 1),
+	}
+
+	if err := n.setup(); err != nil {
+		syscall.Close(fd)
+		return nil
+	}
+
+	go n.run()
+	return n
+}
+
+// Set up the epoll instance and the pipe of the notifier.
+func (n *notifier) setup() error {
+	if err := syscall.Pipe2(n.pipe[:], syscall.O_CLOEXEC); err != nil {
+		return err
+	}
+
+	epfd, err := syscall.EpollCreate1(syscall.EPOLL_CLOEXEC)
+	if err != nil {
+		syscall.Close(n.pipe[0])
+		syscall.Close(n.pipe[1])
+		return err
+	}
+
+	n.epfd = epfd
+	for _, fd := range []int{n.fd, n.pipe[0]} {
+		event := syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(fd)}
+		if err := syscall.EpollCtl(epfd, syscall.EPOLL_CTL_ADD, fd, &event); err != nil {
+			syscall.Close(epfd)
+			syscall.Close(n.pipe[0])
+			syscall.Close(n.pipe[1])
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Wait for inotify events and signal them on the wake channel until the
+// notifier is closed, after which all resources of the notifier are released.
+func (n *notifier) run() {
+	defer func() {
+		syscall.Close(n.epfd)
+		syscall.Close(n.fd)
+		syscall.Close(n.pipe[0])
+	}()
+
+	buf := make([]byte, 4096)
+	events := make([]syscall.EpollEvent, 2)
+	for {
+		count, err := syscall.EpollWait(n.epfd, events, -1)
+		if err == syscall.EINTR {
+			continue
+		} else if err != nil {
+			return
+		}
+
+		for _, event := range events[:count] {
+			if int(event.Fd) == n.pipe[0] {
+				return
+			}
+
+			for {
+				if size, err := syscall.Read(n.fd, buf); err != nil || size <= 0 {
+					break
+				}
+			}
+
+			select {
+			case n.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// Watch the directory `dir` for changes. Errors are ignored as the Watcher will
+// still notice changes by polling.
+func (n *notifier) watch(dir string) {
+	if n == nil || n.dirs[dir] {
+		return
+	}
+
+	n.dirs[dir] = true
+	_, _ = syscall.InotifyAddWatch(n.fd, dir, notifyMask)
+}
+
+// Get the channel on which changes are signalled.
+func (n *notifier) wakeups() <-chan struct{} {
+	if n == nil {
+		return nil
+	}
+
+	return n.wake
+}
+
+// Stop watching for changes.
+func (n *notifier) close() (err error) {
+	if n == nil {
+		return nil
+	}
+
+	n.once.Do(func() {
+		_, err = syscall.Write(n.pipe[1], []byte{0})
+		syscall.Close(n.pipe[1])
+	})
+
+	return err
+}
@@ -0,0 +1,26 @@
+// +build !linux
+
+package fs
+
+// The notifier type notifies a Watcher of changes in the directories it watches.
+// This is not supported on this platform, so a Watcher only polls.
+type notifier struct{}
+
+// Create a new notifier. This is not supported on this platform.
+func newNotifier() *notifier {
+	return nil
+}
+
+// Watch the directory `dir` for changes. This is not supported on this
+// platform.
+func (n *notifier) watch(dir string) {}
+
+// Get the channel on which changes are signalled, which is never.
+func (n *notifier) wakeups() <-chan struct{} {
+	return nil
+}
+
+// Stop watching for changes.
+func (n *notifier) close() error {
+	return nil
+}
@@ -0,0 +1,220 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// Check that the paths `changed` equal the `expected` paths.
+func checkChanged(t *testing.T, changed []string, expected ...string) {
+	t.Helper()
+
+	if len(changed) != len(expected) {
+		t.Fatalf("Unexpected changed files (got %q)", changed)
+	}
+
+	for i, path := range expected {
+		if changed[i] != path {
+			t.Errorf("Unexpected changed file at %d (got '%s')", i, changed[i])
+		}
+	}
+}
+
+// Write `content` to the file at `path` and get the state of the file as
+// written.
+func writeAndStat(t *testing.T, path, content string) os.FileInfo {
+	t.Helper()
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Could not write the file (%s)", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Could not stat the file (%s)", err)
+	}
+
+	return info
+}
+
+func TestGetBaseDir(t *testing.T) {
+	cases := map[string]string{
+		"foo.txt":               ".",
+		filepath.Join("a", "b"): "a",
+		"*.txt":                 ".",
+		"a/*.txt":               filepath.FromSlash("a/"),
+		"a/b/**/*.txt":          filepath.FromSlash("a/b/"),
+	}
+
+	for pattern, expected := range cases {
+		if dir := getBaseDir(pattern); dir != expected {
+			t.Errorf("Unexpected base dir for '%s' (got '%s')", pattern, dir)
+		}
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	path, cleanup := createTempFile(t, "foo.txt", "Hello world")
+	defer cleanup()
+
+	dir := filepath.Dir(path)
+	w := NewWatcher(time.Hour, WalkOptions{}, filepath.Join(dir, "*.txt"))
+	defer w.Close()
+
+	changed, errs := w.Poll()
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors (got %v)", errs)
+	}
+
+	checkChanged(t, changed, path)
+
+	t.Run("No changes", func(t *testing.T) {
+		changed, _ := w.Poll()
+		checkChanged(t, changed)
+	})
+	t.Run("Modified file", func(t *testing.T) {
+		if err := ioutil.WriteFile(path, []byte("Hello there world"), 0644); err != nil {
+			t.Fatalf("Could not write the file (%s)", err)
+		}
+
+		changed, _ := w.Poll()
+		checkChanged(t, changed, path)
+	})
+	t.Run("New file", func(t *testing.T) {
+		other := filepath.Join(dir, "bar.txt")
+		if err := ioutil.WriteFile(other, []byte("Hey"), 0644); err != nil {
+			t.Fatalf("Could not write the file (%s)", err)
+		}
+
+		changed, _ := w.Poll()
+		checkChanged(t, changed, other)
+
+		files := w.Files()
+		if len(files) != 2 {
+			t.Errorf("Unexpected files (got %q)", files)
+		}
+	})
+	t.Run("Deleted file", func(t *testing.T) {
+		other := filepath.Join(dir, "bar.txt")
+		if err := os.Remove(other); err != nil {
+			t.Fatalf("Could not remove the file (%s)", err)
+		}
+
+		changed, _ := w.Poll()
+		checkChanged(t, changed)
+
+		files := w.Files()
+		if len(files) != 1 || files[0] != path {
+			t.Errorf("Unexpected files (got %q)", files)
+		}
+	})
+	t.Run("Ignored change", func(t *testing.T) {
+		written := writeAndStat(t, path, "Hey")
+		w.Ignore(path, written)
+
+		changed, _ := w.Poll()
+		checkChanged(t, changed)
+	})
+	t.Run("Change after ignored change", func(t *testing.T) {
+		written := writeAndStat(t, path, "Hey there")
+		if err := os.Remove(path); err != nil {
+			t.Fatalf("Could not remove the file (%s)", err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte("Hi"), 0644); err != nil {
+			t.Fatalf("Could not write the file (%s)", err)
+		}
+
+		w.Ignore(path, written)
+
+		changed, _ := w.Poll()
+		checkChanged(t, changed, path)
+	})
+}
+
+func TestWatcherAddFiles(t *testing.T) {
+	path, cleanup := createTempFile(t, "foo.csv", "cat,dog")
+	defer cleanup()
+
+	dir := filepath.Dir(path)
+	other := filepath.Join(dir, "bar.txt")
+	if err := ioutil.WriteFile(other, []byte("Hey"), 0644); err != nil {
+		t.Fatalf("Could not write the file (%s)", err)
+	}
+
+	options := WalkOptions{Extensions: []string{"txt"}}
+	w := NewWatcher(time.Hour, options, filepath.Join(dir, "*"))
+	defer w.Close()
+
+	w.AddFiles(path)
+
+	changed, errs := w.Poll()
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors (got %v)", errs)
+	}
+
+	checkChanged(t, changed, other, path)
+}
+
+func TestWatcherPollMalformedPattern(t *testing.T) {
+	w := NewWatcher(time.Hour, WalkOptions{}, "[")
+	defer w.Close()
+
+	if _, errs := w.Poll(); len(errs) == 0 {
+		t.Error("Expected an error for a malformed glob")
+	}
+}
+
+func TestWatcherWait(t *testing.T) {
+	t.Run("Interval", func(t *testing.T) {
+		w := NewWatcher(time.Millisecond, WalkOptions{})
+		defer w.Close()
+
+		if !w.Wait(make(chan struct{})) {
+			t.Error("Expected the Watcher to poll again")
+		}
+	})
+	t.Run("Done", func(t *testing.T) {
+		w := NewWatcher(time.Hour, WalkOptions{})
+		defer w.Close()
+
+		done := make(chan struct{})
+		close(done)
+		if w.Wait(done) {
+			t.Error("Expected the Watcher to stop")
+		}
+	})
+	t.Run("Notified", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("Notifications are only supported on Linux")
+		}
+
+		path, cleanup := createTempFile(t, "foo.txt", "Hello world")
+		defer cleanup()
+
+		w := NewWatcher(time.Hour, WalkOptions{}, path)
+		defer w.Close()
+
+		w.Poll()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = ioutil.WriteFile(path, []byte("Hey"), 0644)
+		}()
+
+		result := make(chan bool)
+		go func() { result <- w.Wait(make(chan struct{})) }()
+
+		select {
+		case ok := <-result:
+			if !ok {
+				t.Error("Expected the Watcher to poll again")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the Watcher to be notified of the change")
+		}
+	})
+}
@@ -0,0 +1,64 @@
+// +build linux
+
+package fs
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+)
+
+// Get the names of the extended attributes of the file at `path`.
+func listXattrs(path string) ([]string, error) {
+	size, err := syscall.Listxattr(path, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+
+	buf := make([]byte, size)
+	size, err = syscall.Listxattr(path, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+
+	return names, nil
+}
+
+// Get the value of the extended attribute `name` of the file at `path`.
+func getXattr(path, name string) ([]byte, error) {
+	size, err := syscall.Getxattr(path, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value := make([]byte, size)
+	size, err = syscall.Getxattr(path, name, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value[:size], nil
+}
+
+// Preserve the extended attributes of the file at `path` on the file `temp`.
+// This is done on a best-effort basis, as the file system may not support
+// extended attributes and setting some attributes may not be permitted.
+func preserveXattrs(temp *os.File, path string) {
+	names, err := listXattrs(path)
+	if err != nil {
+		return
+	}
+
+	for _, name := range names {
+		if value, err := getXattr(path, name); err == nil {
+			_ = syscall.Setxattr(temp.Name(), name, value, 0)
+		}
+	}
+}
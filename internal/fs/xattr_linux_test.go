@@ -0,0 +1,29 @@
+// +build linux
+
+package fs
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestFileWritePreservesXattrs(t *testing.T) {
+	path, cleanup := createTempFile(t, "foo.txt", "Hello world!")
+	defer cleanup()
+
+	name, value := "user.wordrow", "foobar"
+	if err := syscall.Setxattr(path, name, []byte(value), 0); err != nil {
+		t.Skipf("Extended attributes are not supported (%s)", err)
+	}
+
+	writeWithFile(t, path, OReadWrite, "Hey")
+
+	result, err := getXattr(path, name)
+	if err != nil {
+		t.Fatalf("Could not get the extended attribute (%s)", err)
+	}
+
+	if string(result) != value {
+		t.Errorf("Unexpected value of the extended attribute (got '%s')", result)
+	}
+}
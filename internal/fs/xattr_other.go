@@ -0,0 +1,9 @@
+// +build !linux
+
+package fs
+
+import "os"
+
+// Preserve the extended attributes of the file at `path` on the file `temp`.
+// This is not supported on this platform.
+func preserveXattrs(temp *os.File, path string) {}
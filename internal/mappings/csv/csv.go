@@ -3,52 +3,185 @@ package csv
 import (
 	"bufio"
 	"bytes"
+	"encoding/csv"
+	"io"
+	"unicode/utf8"
 
 	"github.com/ericcornelissen/wordrow/internal/common"
 	"github.com/ericcornelissen/wordrow/internal/mappings/errors"
 )
 
-// Byte-slice representing a comma (',').
-var comma = []byte{','}
+// Byte-slice representing the UTF-8 Byte Order Mark (BOM).
+var bom = []byte{0xEF, 0xBB, 0xBF}
 
-// Parse a single row of a CSV file and add it to the `mapping`.
+// Byte-slice representing the start of a comment line ('#').
+var commentStart = []byte{'#'}
+
+// Byte-slice representing a double quote ('"').
+var quote = []byte{'"'}
+
+// CheckSeparator checks whether `separator` can be used to separate values, see
+// ParseSeparated.
+//
+// The error will be set if the separator is a character that cannot be used as
+// separator, i.e. a quote, the start of a comment, or a line break.
+func CheckSeparator(separator rune) error {
+	switch separator {
+	case 0, utf8.RuneError, '"', '#', '\r', '\n':
+		return errors.Newf("Invalid separator %q", separator)
+	}
+
+	return nil
+}
+
+// Check whether the `values` of a row make up a header row, i.e. whether all
+// values but the last are "from" and the last value is "to", ignoring case.
+func isHeader(values [][]byte) bool {
+	last := len(values) - 1
+	for _, value := range values[:last] {
+		if !bytes.EqualFold(value, []byte("from")) {
+			return false
+		}
+	}
+
+	return bytes.EqualFold(values[last], []byte("to"))
+}
+
+// Check whether the `line` is skipped, i.e. whether it is empty or a comment.
+func isSkipped(line []byte) bool {
+	trimmed := bytes.TrimSpace(line)
+	return len(trimmed) == 0 || bytes.HasPrefix(trimmed, commentStart)
+}
+
+// Read a single row from the `reader`. A row is a single line, unless a quoted
+// value spans multiple lines. Empty lines and comment lines before the row are
+// skipped. It returns the row, the number of lines skipped, and the number of
+// lines of the row.
+//
+// The error is set to io.EOF if there are no more rows.
+func readRow(reader *bufio.Reader) ([]byte, int, int, error) {
+	var row []byte
+	var skipped, lines int
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, skipped, lines, err
+		}
+
+		if len(line) == 0 {
+			if len(row) == 0 {
+				return nil, skipped, lines, io.EOF
+			}
+
+			return row, skipped, lines, nil
+		}
+
+		if len(row) == 0 && isSkipped(line) {
+			skipped++
+			continue
+		}
+
+		row, lines = append(row, line...), lines+1
+		if bytes.Count(row, quote)%2 == 0 || err == io.EOF {
+			return row, skipped, lines, nil
+		}
+	}
+}
+
+// Parse a single `row` of a file with values separated by `separator` into its
+// values. Values may be quoted as specified by RFC 4180 and are trimmed.
 //
 // The error will be set if the row has an unexpected format, for example an
 // incorrect number of columns.
-func parseRow(row []byte, mapping map[string]string) error {
+func parseRow(row []byte, separator rune) ([][]byte, error) {
+	reader := csv.NewReader(bytes.NewReader(row))
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
 	rowValuesCount := 2
 
-	rowValues := bytes.Split(row, comma)
-	if len(rowValues) < rowValuesCount {
-		return errors.NewIncorrectFormat(row)
+	firstLine := bytes.SplitN(row, []byte{'\n'}, 2)[0]
+	record, err := reader.Read()
+	if err != nil || len(record) < rowValuesCount {
+		return nil, errors.NewIncorrectFormat(bytes.TrimSpace(firstLine))
+	}
+
+	rowValues := make([][]byte, len(record))
+	for i, value := range record {
+		rowValues[i] = []byte(value)
 	}
 
-	rowValues, err := common.TrimValues(rowValues)
+	rowValues, err = common.TrimValues(rowValues)
 	if err != nil {
-		return errors.NewMissingValue(row)
+		return nil, errors.NewMissingValue(bytes.TrimSpace(firstLine))
 	}
 
-	common.AddValuesToMap(mapping, rowValues)
-	return nil
+	return rowValues, nil
 }
 
-// Parse a Comma Separated Values (CSV) file into a map[string]string.
+// ParseSeparated parses a file with values separated by `separator` into a list
+// of mappings, in the order in which they are defined. Every row maps all its
+// values but the last to the last value. See Parse for the details of the
+// format.
 //
-// The error will be set if any error occurred while parsing the CSV file.
-func Parse(reader *bufio.Reader) (mapping map[string]string, err error) {
-	mapping = make(map[string]string, 1)
+// The error will be set if the separator is invalid, see CheckSeparator, or if
+// any error occurred while parsing the file.
+func ParseSeparated(
+	reader *bufio.Reader,
+	separator rune,
+) ([]common.Mapping, error) {
+	if err := CheckSeparator(separator); err != nil {
+		return nil, err
+	}
 
-	var line []byte
-	for ; err == nil; line, _, err = reader.ReadLine() {
-		if len(bytes.TrimSpace(line)) == 0 {
-			continue
+	var mappings common.MappingList
+	if start, _ := reader.Peek(len(bom)); bytes.Equal(start, bom) {
+		reader.Discard(len(bom))
+	}
+
+	for n, first := 1, true; ; first = false {
+		row, skipped, lines, err := readRow(reader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return mappings.Mappings(), err
 		}
 
-		err := parseRow(line, mapping)
+		n += skipped
+
+		values, err := parseRow(row, separator)
 		if err != nil {
-			return mapping, err
+			return mappings.Mappings(), err
+		}
+
+		if !first || !isHeader(values) {
+			mappings.AddValues(values, n)
 		}
+
+		n += lines
 	}
 
-	return mapping, nil
+	return mappings.Mappings(), nil
+}
+
+// Parse a Comma Separated Values (CSV) file into a list of mappings, in the
+// order in which they are defined.
+//
+// Values may be quoted as specified by RFC 4180, e.g. to include a comma in a
+// value. Empty lines and lines starting with '#' are ignored, as are a header
+// row "from,to" and a UTF-8 Byte Order Mark at the start of the file.
+//
+// The error will be set if any error occurred while parsing the CSV file.
+func Parse(reader *bufio.Reader) ([]common.Mapping, error) {
+	return ParseSeparated(reader, ',')
+}
+
+// ParseTSV parses a Tab Separated Values (TSV) file into a list of mappings, in
+// the order in which they are defined. Apart from the separator, the format is
+// the same as that of a CSV file, see Parse.
+//
+// The error will be set if any error occurred while parsing the TSV file.
+func ParseTSV(reader *bufio.Reader) ([]common.Mapping, error) {
+	return ParseSeparated(reader, '\t')
 }
@@ -137,3 +137,194 @@ func TestCsvToFewColumns(t *testing.T) {
 		t.Errorf("Incorrect error message for (got '%s')", err)
 	}
 }
+
+func TestCsvLines(t *testing.T) {
+	csv := "cat,dog\n\nhorse,zebra\n"
+
+	reader := NewTestReader(&csv)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := []int{1, 3}
+	for i, line := range expected {
+		if mapping[i].Line != line {
+			t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+		}
+	}
+}
+
+func TestCsvQuotedValues(t *testing.T) {
+	t.Run("Separator in value", func(t *testing.T) {
+		csv := `"e.g.,","for example,"`
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"e.g.,", "for example,"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Escaped quotes", func(t *testing.T) {
+		csv := `"say ""hi""", "say ""hello"""`
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{`say "hi"`, `say "hello"`}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Value spanning lines", func(t *testing.T) {
+		csv := "\"hello\nworld\",hey\ncat,dog\n"
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"hello\nworld", "hey"}, {"cat", "dog"}}
+		CheckMapping(t, mapping, expected)
+
+		if mapping[1].Line != 3 {
+			t.Errorf("Incorrect line for '%s' (got %d)", mapping[1].From, mapping[1].Line)
+		}
+	})
+	t.Run("Many-to-one", func(t *testing.T) {
+		csv := `"a, b", c, "d"`
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"a, b", "d"}, {"c", "d"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Unterminated quote", func(t *testing.T) {
+		csv := "\"cat,dog\nhorse,zebra"
+
+		reader := NewTestReader(&csv)
+		_, err := Parse(reader)
+		if err == nil {
+			t.Fatal("Error should be set for an unterminated quote")
+		}
+
+		if !stringsx.Contains(err.Error(), "Incorrect format") {
+			t.Errorf("Incorrect error message for (got '%s')", err)
+		}
+	})
+}
+
+func TestCsvComments(t *testing.T) {
+	csv := `# mapping.csv
+		cat,dog
+		# horse,zebra
+		"#hashtag",tag
+	`
+
+	reader := NewTestReader(&csv)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat", "dog"}, {"#hashtag", "tag"}}
+	CheckMapping(t, mapping, expected)
+
+	expectedLines := []int{2, 4}
+	for i, line := range expectedLines {
+		if mapping[i].Line != line {
+			t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+		}
+	}
+}
+
+func TestCsvHeader(t *testing.T) {
+	t.Run("Header row", func(t *testing.T) {
+		csv := "From, To\ncat,dog\n"
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Not the first row", func(t *testing.T) {
+		csv := "cat,dog\nfrom,to\n"
+
+		reader := NewTestReader(&csv)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}, {"from", "to"}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestCsvByteOrderMark(t *testing.T) {
+	csv := "\xEF\xBB\xBFcat,dog"
+
+	reader := NewTestReader(&csv)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat", "dog"}}
+	CheckMapping(t, mapping, expected)
+}
+
+func TestParseSeparated(t *testing.T) {
+	csv := "cat;dog\n\"a;b\";c\n"
+
+	reader := NewTestReader(&csv)
+	mapping, err := ParseSeparated(reader, ';')
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat", "dog"}, {"a;b", "c"}}
+	CheckMapping(t, mapping, expected)
+}
+
+func TestParseSeparatedInvalidSeparator(t *testing.T) {
+	for _, separator := range []rune{'"', '#', '\n', '\r', 0} {
+		csv := "cat;dog\n"
+
+		reader := NewTestReader(&csv)
+		_, err := ParseSeparated(reader, separator)
+		if err == nil {
+			t.Fatalf("Error should be set for %q", separator)
+		}
+
+		if !stringsx.Contains(err.Error(), "Invalid separator") {
+			t.Errorf("Incorrect error message for %q (got '%s')", separator, err)
+		}
+	}
+}
+
+func TestParseTSV(t *testing.T) {
+	tsv := "from\tto\ncat, dog\thorse\n\"a\tb\"\tc\n"
+
+	reader := NewTestReader(&tsv)
+	mapping, err := ParseTSV(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat, dog", "horse"}, {"a\tb", "c"}}
+	CheckMapping(t, mapping, expected)
+}
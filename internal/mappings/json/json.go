@@ -0,0 +1,320 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/mappings/errors"
+)
+
+// The rule type represents a single rule in an array of rules.
+type rule struct {
+	common.Metadata
+
+	// The value(s) to be replaced, either a string or a list of strings.
+	From json.RawMessage `json:"from"`
+
+	// The value to replace the From value(s) with.
+	To json.RawMessage `json:"to"`
+}
+
+// The parser type keeps track of the content of a JSON file being parsed, so
+// that errors can report where in the content they occurred.
+type parser struct {
+	// The content of the JSON file.
+	content []byte
+
+	// The decoder reading the content.
+	decoder *json.Decoder
+}
+
+// Get the line and column of the byte at `offset` in the content. Both the line
+// and the column start at 1, and the column is counted in characters.
+func (p *parser) position(offset int64) (line, column int) {
+	before := p.content[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+
+	line = bytes.Count(before, []byte{'\n'}) + 1
+	column = utf8.RuneCount(before[lineStart:]) + 1
+	return line, column
+}
+
+// Get the offset of the next value in the content, skipping any whitespace and
+// separators following the previous value.
+func (p *parser) next() int64 {
+	offset := p.decoder.InputOffset()
+	for offset < int64(len(p.content)) {
+		switch p.content[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+// Create an error with the `message` for the value at `offset`.
+func (p *parser) errorAt(offset int64, message string) error {
+	line, column := p.position(offset)
+	return errors.Newf("%s (on line %d, column %d)", message, line, column)
+}
+
+// Convert an error of the decoder into an error that reports where in the
+// content it occurred.
+func (p *parser) convertError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	offset := int64(len(p.content))
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		offset = syntaxErr.Offset - 1
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return p.errorAt(offset, "Invalid JSON: "+err.Error())
+}
+
+// Parse a JSON value that is a string into its value. It returns false if the
+// JSON value is not a string.
+func parseString(raw json.RawMessage) ([]byte, bool) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, false
+	}
+
+	return []byte(value), true
+}
+
+// Parse the `from` and `to` values of a rule into a list of values such that
+// all values but the last are mapped to the last value. It returns false if
+// either has an incorrect type.
+func parseRule(r *rule) ([][]byte, bool) {
+	to, ok := parseString(r.To)
+	if !ok {
+		return nil, false
+	}
+
+	if from, ok := parseString(r.From); ok {
+		return [][]byte{from, to}, true
+	}
+
+	var from []string
+	if err := json.Unmarshal(r.From, &from); err != nil || len(from) == 0 {
+		return nil, false
+	}
+
+	values := make([][]byte, 0, len(from)+1)
+	for _, value := range from {
+		values = append(values, []byte(value))
+	}
+
+	return append(values, to), true
+}
+
+// Add the `values`, defined at `offset`, to the `mappings` such that all values
+// but the last are mapped to the last value, with the `metadata`.
+//
+// The error will be set if any of the values is empty.
+func (p *parser) addValues(
+	mappings *common.MappingList,
+	values [][]byte,
+	metadata *common.Metadata,
+	offset int64,
+) error {
+	values, err := common.TrimValues(values)
+	if err != nil {
+		return p.errorAt(offset, "Missing value")
+	}
+
+	line, _ := p.position(offset)
+	mapping := common.Mapping{Line: line}
+	metadata.ApplyTo(&mapping)
+
+	last := len(values) - 1
+	mapping.To = string(values[last])
+	for _, from := range values[:last] {
+		mapping.From = string(from)
+		mappings.Set(mapping)
+	}
+
+	return nil
+}
+
+// Check that the keys of the object `raw`, defined at `offset`, are all fields
+// a rule may have.
+//
+// The error will be set if the value is not an object or any key is unknown.
+func (p *parser) checkKeys(raw json.RawMessage, offset int64) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return p.errorAt(offset, "Incorrect format")
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !common.IsOneOf(key, common.RuleFields) {
+			return p.errorAt(offset, "Unknown field '"+key+"'")
+		}
+	}
+
+	return nil
+}
+
+// Parse the members of an object mapping each key to a value, after the opening
+// brace of the object has been read.
+//
+// The error will be set if any member is not a mapping to a string.
+func (p *parser) parseObject() ([]common.Mapping, error) {
+	var mappings common.MappingList
+	for p.decoder.More() {
+		offset := p.next()
+
+		token, err := p.decoder.Token()
+		if err != nil {
+			return mappings.Mappings(), p.convertError(err)
+		}
+
+		var value json.RawMessage
+		if err := p.decoder.Decode(&value); err != nil {
+			return mappings.Mappings(), p.convertError(err)
+		}
+
+		key, _ := token.(string)
+		to, ok := parseString(value)
+		if !ok {
+			return mappings.Mappings(), p.errorAt(offset, "Incorrect format")
+		}
+
+		values := [][]byte{[]byte(key), to}
+		metadata := new(common.Metadata)
+		if err := p.addValues(&mappings, values, metadata, offset); err != nil {
+			return mappings.Mappings(), err
+		}
+	}
+
+	return mappings.Mappings(), nil
+}
+
+// Parse the elements of an array of rules, after the opening bracket of the
+// array has been read.
+//
+// The error will be set if any element is not a valid rule.
+func (p *parser) parseArray() ([]common.Mapping, error) {
+	var mappings common.MappingList
+	for p.decoder.More() {
+		offset := p.next()
+
+		var raw json.RawMessage
+		if err := p.decoder.Decode(&raw); err != nil {
+			return mappings.Mappings(), p.convertError(err)
+		}
+
+		if err := p.checkKeys(raw, offset); err != nil {
+			return mappings.Mappings(), err
+		}
+
+		var r rule
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return mappings.Mappings(), p.errorAt(offset, "Incorrect format")
+		}
+
+		values, ok := parseRule(&r)
+		if !ok {
+			return mappings.Mappings(), p.errorAt(offset, "Incorrect format")
+		}
+
+		if err := r.Metadata.Validate(); err != nil {
+			return mappings.Mappings(), p.errorAt(offset, err.Error())
+		}
+
+		if !r.Metadata.IsEnabled() {
+			continue
+		}
+
+		if err := p.addValues(&mappings, values, &r.Metadata, offset); err != nil {
+			return mappings.Mappings(), err
+		}
+	}
+
+	return mappings.Mappings(), nil
+}
+
+// Parse a JSON file into a list of mappings, in the order in which they are
+// defined. The file contains either an object mapping values to their
+// replacement, or an array of rules. Every rule is an object with a `from`
+// value, which is either a string or a list of strings, and a `to` value. It may
+// also have a `description`, a `severity` ("error", "warning", or "info"), and
+// may set `case-sensitive` or `enabled`. Rules that are not enabled are omitted.
+// For example:
+//
+//	{ "dog": "cat", "canary": "parrot" }
+//
+//	[
+//	  { "from": "dog", "to": "cat" },
+//	  { "from": ["canary", "finch"], "to": "parrot", "severity": "info" }
+//	]
+//
+// The error will be set if any error occurred while parsing the JSON file. It
+// reports the line and column at which the error occurred.
+func Parse(reader *bufio.Reader) ([]common.Mapping, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{
+		content: content,
+		decoder: json.NewDecoder(bytes.NewReader(content)),
+	}
+
+	offset := p.next()
+	token, err := p.decoder.Token()
+	if err != nil {
+		return nil, p.convertError(err)
+	}
+
+	var mappings []common.Mapping
+	switch token {
+	case json.Delim('{'):
+		mappings, err = p.parseObject()
+	case json.Delim('['):
+		mappings, err = p.parseArray()
+	default:
+		return nil, p.errorAt(offset, "Incorrect format")
+	}
+
+	if err != nil {
+		return mappings, err
+	}
+
+	if _, err := p.decoder.Token(); err != nil {
+		return mappings, p.convertError(err)
+	}
+
+	if end := p.next(); end < int64(len(content)) {
+		return mappings, p.errorAt(end, "Incorrect format")
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,216 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+	. "github.com/ericcornelissen/wordrow/internal/mappings/testing"
+)
+
+func TestJsonObject(t *testing.T) {
+	json := `{
+		"cat": "dog",
+		"horse": "zebra"
+	}`
+
+	reader := NewTestReader(&json)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat", "dog"}, {"horse", "zebra"}}
+	CheckMapping(t, mapping, expected)
+
+	expectedLines := []int{2, 3}
+	for i, line := range expectedLines {
+		if mapping[i].Line != line {
+			t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+		}
+	}
+}
+
+func TestJsonArray(t *testing.T) {
+	t.Run("Single from value", func(t *testing.T) {
+		json := `[
+			{ "from": "cat", "to": "dog" },
+			{ "from": "horse", "to": "zebra", "description": "Stripes" }
+		]`
+
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}, {"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+
+		expectedLines := []int{2, 3}
+		for i, line := range expectedLines {
+			if mapping[i].Line != line {
+				t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+			}
+		}
+	})
+	t.Run("Many from values", func(t *testing.T) {
+		json := `[{ "from": ["cat", "dog"], "to": "horse" }]`
+
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "horse"}, {"dog", "horse"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Whitespace and special characters", func(t *testing.T) {
+		json := `[{ "from": " e.g., ", "to": "for \"example\"," }]`
+
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"e.g.,", `for "example",`}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestJsonMetadata(t *testing.T) {
+	t.Run("Rule metadata", func(t *testing.T) {
+		json := `[{
+			"from": "JS",
+			"to": "JavaScript",
+			"description": "Use the full name",
+			"severity": "warning",
+			"case-sensitive": true
+		}]`
+
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"JS", "JavaScript"}}
+		CheckMapping(t, mapping, expected)
+
+		if !mapping[0].CaseSensitive {
+			t.Error("Mapping should be case sensitive")
+		}
+
+		if mapping[0].Severity != "warning" {
+			t.Errorf("Incorrect severity (got '%s')", mapping[0].Severity)
+		}
+
+		if mapping[0].Description != "Use the full name" {
+			t.Errorf("Incorrect description (got '%s')", mapping[0].Description)
+		}
+	})
+	t.Run("Disabled rules", func(t *testing.T) {
+		json := `[
+			{ "from": "cat", "to": "dog", "enabled": false },
+			{ "from": "horse", "to": "zebra", "enabled": true }
+		]`
+
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestJsonEmpty(t *testing.T) {
+	for _, json := range []string{"", "{}", "[]", "  \n"} {
+		reader := NewTestReader(&json)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for %q (got '%s')", json, err)
+		}
+
+		if len(mapping) != 0 {
+			t.Errorf("Unexpected mapping size for %q (got %d)", json, len(mapping))
+		}
+	}
+}
+
+func TestJsonErrors(t *testing.T) {
+	cases := map[string]struct {
+		json     string
+		expected string
+	}{
+		"Syntax error": {
+			json:     "{\n  \"cat\": \"dog\",\n  \"horse\" \"zebra\"\n}",
+			expected: "(on line 3, column 11)",
+		},
+		"Unexpected end": {
+			json:     "[\n  { \"from\": \"cat\", \"to\": \"dog\" }",
+			expected: "Invalid JSON",
+		},
+		"Not an object or array": {
+			json:     `"cat"`,
+			expected: "Incorrect format (on line 1, column 1)",
+		},
+		"Incorrect to value": {
+			json:     "{\n  \"cat\": 42\n}",
+			expected: "Incorrect format (on line 2, column 3)",
+		},
+		"Incorrect from value": {
+			json:     "[\n  { \"from\": 42, \"to\": \"dog\" }\n]",
+			expected: "Incorrect format (on line 2, column 3)",
+		},
+		"Missing to value": {
+			json:     `[{ "from": "cat" }]`,
+			expected: "Incorrect format (on line 1, column 2)",
+		},
+		"Empty from list": {
+			json:     `[{ "from": [], "to": "dog" }]`,
+			expected: "Incorrect format (on line 1, column 2)",
+		},
+		"Empty value": {
+			json:     `[{ "from": ["cat", " "], "to": "dog" }]`,
+			expected: "Missing value (on line 1, column 2)",
+		},
+		"Unknown rule field": {
+			json:     "[\n  { \"from\": \"cat\", \"to\": \"dog\", \"case_sensitive\": true }\n]",
+			expected: "Unknown field 'case_sensitive' (on line 2, column 3)",
+		},
+		"Incorrect metadata": {
+			json:     `[{ "from": "cat", "to": "dog", "enabled": "no" }]`,
+			expected: "Incorrect format (on line 1, column 2)",
+		},
+		"Unknown severity": {
+			json:     `[{ "from": "cat", "to": "dog", "severity": "fatal" }]`,
+			expected: "Unknown severity 'fatal' (on line 1, column 2)",
+		},
+		"Rule is not an object": {
+			json:     `["cat", "dog"]`,
+			expected: "Incorrect format (on line 1, column 2)",
+		},
+		"Trailing data": {
+			json:     `{ "cat": "dog" } x`,
+			expected: "on line 1, column 18",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			reader := NewTestReader(&c.json)
+			_, err := Parse(reader)
+			if err == nil {
+				t.Fatal("Error should be set for this test")
+			}
+
+			if !stringsx.Contains(err.Error(), c.expected) {
+				t.Errorf("Incorrect error message (got '%s')", err)
+			}
+		})
+	}
+}
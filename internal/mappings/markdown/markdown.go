@@ -15,6 +15,26 @@ var pipeByte = []byte{'|'}
 // Regular expression of a MarkDown table row.
 var tableDividerExpr = regexp.MustCompile(`^\s*\|(\s*-+\s*\|){2,}\s*$`)
 
+// The lineReader type wraps a bufio.Reader to keep track of the number of the
+// line that was read last.
+type lineReader struct {
+	*bufio.Reader
+
+	// The number of the line that was read last, starting at 1.
+	line int
+}
+
+// ReadLine reads a single line and keeps track of its number, see ReadLine of
+// bufio.Reader.
+func (r *lineReader) ReadLine() ([]byte, bool, error) {
+	line, isPrefix, err := r.Reader.ReadLine()
+	if err == nil && !isPrefix {
+		r.line++
+	}
+
+	return line, isPrefix, err
+}
+
 // Check whether or not a line in a MarkDown file is part of a table.
 func isTableRow(row []byte) bool {
 	row = bytes.TrimSpace(row)
@@ -56,7 +76,7 @@ func verifyTableHeader(headerLine []byte) (err error) {
 // Parse the divider of a MarkDown table.
 //
 // The error will be set if the table divider has an unexpected format.
-func verifyTableDivider(reader *bufio.Reader) (err error) {
+func verifyTableDivider(reader *lineReader) (err error) {
 	problem := false
 
 	dividerLine, _, err := reader.ReadLine()
@@ -75,13 +95,10 @@ func verifyTableDivider(reader *bufio.Reader) (err error) {
 	return err
 }
 
-// Parse a MarkDown table body and put its values into the `mapping`.
+// Parse a MarkDown table body and add its values to the `mappings`.
 //
 // The error will be set if any table row has an incorrect format.
-func parseTableBody(
-	reader *bufio.Reader,
-	mapping map[string]string,
-) (err error) {
+func parseTableBody(reader *lineReader, mappings *common.MappingList) error {
 	row, _, err := reader.ReadLine()
 	if err != nil || !isTableRow(row) {
 		return errors.Newf("Missing table body (in '%s')", row)
@@ -97,50 +114,47 @@ func parseTableBody(
 			return err
 		}
 
-		common.AddValuesToMap(mapping, rowValues)
+		mappings.AddValues(rowValues, reader.line)
 	}
 
 	return nil
 }
 
-// Parse a MarkDown table and put its values into the `mapping`.
+// Parse a MarkDown table and add its values to the `mappings`.
 //
 // The error will be set if the table head or any table row has an incorrect
 // format.
-func parseTable(reader *bufio.Reader, mapping map[string]string) error {
+func parseTable(reader *lineReader, mappings *common.MappingList) error {
 	if err := verifyTableDivider(reader); err != nil {
 		return err
 	}
 
-	err := parseTableBody(reader, mapping)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return parseTableBody(reader, mappings)
 }
 
-// Parse a MarkDown (MD) formatted file into a map[string]string.
+// Parse a MarkDown (MD) formatted file into a list of mappings, in the order in
+// which they are defined.
 //
 // The error will be set if any error occurred while parsing the MD file.
-func Parse(reader *bufio.Reader) (mapping map[string]string, err error) {
-	mapping = make(map[string]string, 1)
+func Parse(bufReader *bufio.Reader) ([]common.Mapping, error) {
+	reader := &lineReader{Reader: bufReader}
 
+	var mappings common.MappingList
 	var line []byte
+	var err error
 	for ; err == nil; line, _, err = reader.ReadLine() {
 		if !isTableRow(line) {
 			continue
 		}
 
 		if err := verifyTableHeader(line); err != nil {
-			return mapping, err
+			return mappings.Mappings(), err
 		}
 
-		err := parseTable(reader, mapping)
-		if err != nil {
-			return mapping, err
+		if err := parseTable(reader, &mappings); err != nil {
+			return mappings.Mappings(), err
 		}
 	}
 
-	return mapping, nil
+	return mappings.Mappings(), nil
 }
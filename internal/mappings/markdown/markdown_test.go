@@ -262,3 +262,20 @@ func TestMarkdownIncompleteTableEndOfFile(t *testing.T) {
 		}
 	})
 }
+
+func TestMarkDownLines(t *testing.T) {
+	markdown := "# Title\n\n| from | to |\n| --- | --- |\n| cat | dog |\n| horse | zebra |\n"
+
+	reader := NewTestReader(&markdown)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := []int{5, 6}
+	for i, line := range expected {
+		if mapping[i].Line != line {
+			t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+		}
+	}
+}
@@ -1,18 +1,28 @@
 // Package mappings provides two structures for functionality to parse files
-// into a map[string]string. The supported formats are:
+// into an ordered list of mappings. The supported formats are:
 // - CSV
+// - DSV (with a custom separator, e.g. "dsv;sep=|")
+// - JSON
 // - MarkDown
+// - TOML
+// - TSV
+// - YAML
 package mappings
 
 import (
 	"bufio"
 	"io"
 	"regexp"
+	"unicode/utf8"
 
 	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
 	"github.com/ericcornelissen/wordrow/internal/errors"
 	"github.com/ericcornelissen/wordrow/internal/mappings/csv"
+	"github.com/ericcornelissen/wordrow/internal/mappings/json"
 	"github.com/ericcornelissen/wordrow/internal/mappings/markdown"
+	"github.com/ericcornelissen/wordrow/internal/mappings/toml"
+	"github.com/ericcornelissen/wordrow/internal/mappings/yaml"
 )
 
 var (
@@ -21,50 +31,114 @@ var (
 
 	// Regular expression of names considered as CSV format.
 	csvPattern = regexp.MustCompile(`(?i)\.?csv`)
+
+	// Regular expression of names considered as TSV format.
+	tsvPattern = regexp.MustCompile(`(?i)\.?tsv`)
+
+	// Regular expression of names considered as Delimiter Separated Values
+	// (DSV) format, capturing the separator.
+	dsvPattern = regexp.MustCompile(`(?i)^\.?dsv(?:;sep=(.*))?$`)
+
+	// Regular expression of names considered as JSON format.
+	jsonPattern = regexp.MustCompile(`(?i)\.?json`)
+
+	// Regular expression of names considered as TOML format.
+	tomlPattern = regexp.MustCompile(`(?i)\.?toml`)
+
+	// Regular expression of names considered as YAML format.
+	yamlPattern = regexp.MustCompile(`(?i)\.?ya?ml`)
 )
 
 // A parse function is a function that takes the contents of a file as a string
-// and outputs a map[string]string. If the file is not formatted correctly the
-// function may output an error.
-type parseFunction func(reader *bufio.Reader) (map[string]string, error)
+// and outputs a list of mappings in the order they appear in the file. If the
+// file is not formatted correctly the function may output an error.
+type parseFunction func(reader *bufio.Reader) ([]common.Mapping, error)
+
+// Get the separator rune of a DSV format from its `sep` option. The separator
+// is either a single character or "tab".
+//
+// The error will be set if the separator is missing, if it is not a single
+// character, or if it is a character that cannot be used as separator.
+func getSeparator(sep string) (rune, error) {
+	if sep == "" {
+		return 0, errors.New("Missing separator, use e.g. 'dsv;sep=|' as format")
+	}
+
+	if stringsx.EqualFold(sep, "tab") {
+		return '\t', nil
+	}
+
+	separator, size := utf8.DecodeRuneInString(sep)
+	if size != len(sep) {
+		return 0, errors.Newf("Invalid separator '%s'", sep)
+	}
+
+	if err := csv.CheckSeparator(separator); err != nil {
+		return 0, err
+	}
+
+	return separator, nil
+}
+
+// Get the parseFunction for a DSV file with the separator `sep`.
+func getParserForDSV(sep string) (parseFunction, error) {
+	separator, err := getSeparator(sep)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(reader *bufio.Reader) ([]common.Mapping, error) {
+		return csv.ParseSeparated(reader, separator)
+	}, nil
+}
 
 // Get the parseFunction for a given format.
 func getParserForFormat(format string) (parseFunction, error) {
-	if mdPattern.MatchString(format) {
+	if match := dsvPattern.FindStringSubmatch(format); match != nil {
+		return getParserForDSV(match[1])
+	} else if mdPattern.MatchString(format) {
 		return markdown.Parse, nil
 	} else if csvPattern.MatchString(format) {
 		return csv.Parse, nil
+	} else if tsvPattern.MatchString(format) {
+		return csv.ParseTSV, nil
+	} else if jsonPattern.MatchString(format) {
+		return json.Parse, nil
+	} else if tomlPattern.MatchString(format) {
+		return toml.Parse, nil
+	} else if yamlPattern.MatchString(format) {
+		return yaml.Parse, nil
 	}
 
 	return nil, errors.Newf("Unknown format '%s'", format)
 }
 
-// ParseReader parses a file formatted in a certain way into a
-// map[string]string.
+// ParseReader parses a file formatted in a certain way into a list of mappings.
+// The mappings are in the order in which they appear in the file.
 //
 // The function sets the error if the parsing failed, e.g. when the format is
 // unknown or if content is improperly formatted.
-func ParseReader(reader io.Reader, format string) (map[string]string, error) {
+func ParseReader(reader io.Reader, format string) ([]common.Mapping, error) {
 	parseFn, err := getParserForFormat(format)
 	if err != nil {
 		return nil, err
 	}
 
 	bufReader := bufio.NewReader(reader)
-	mapping, err := parseFn(bufReader)
+	mappings, err := parseFn(bufReader)
 	if err != nil {
 		return nil, err
 	}
 
-	return mapping, nil
+	return mappings, nil
 }
 
-// ParseString parses a file formatted in a certain way into a
-// map[string]string.
+// ParseString parses a file formatted in a certain way into a list of mappings.
+// The mappings are in the order in which they appear in the file.
 //
 // The function sets the error if the parsing failed, e.g. when the format is
 // unknown or if content is improperly formatted.
-func ParseString(s *string, format string) (map[string]string, error) {
+func ParseString(s *string, format string) ([]common.Mapping, error) {
 	reader := stringsx.NewReader(*s)
 	return ParseReader(reader, format)
 }
@@ -4,8 +4,12 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ericcornelissen/stringsx"
 	"github.com/ericcornelissen/wordrow/internal/mappings/csv"
+	"github.com/ericcornelissen/wordrow/internal/mappings/json"
 	"github.com/ericcornelissen/wordrow/internal/mappings/markdown"
+	"github.com/ericcornelissen/wordrow/internal/mappings/toml"
+	"github.com/ericcornelissen/wordrow/internal/mappings/yaml"
 
 	. "github.com/ericcornelissen/wordrow/internal/mappings/testing"
 )
@@ -100,6 +104,173 @@ func TestGetParserForCSVFile(t *testing.T) {
 	})
 }
 
+func TestGetParserForTSVFile(t *testing.T) {
+	check := func(t *testing.T, parseFn parseFunction, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		actual := reflect.ValueOf(parseFn)
+		expected := reflect.ValueOf(csv.ParseTSV)
+		if actual.Pointer() != expected.Pointer() {
+			t.Error("The parser function should be the TSV parse function")
+		}
+	}
+
+	t.Run(".tsv", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".tsv")
+		check(t, parseFn, err)
+	})
+	t.Run(".TSV", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".TSV")
+		check(t, parseFn, err)
+	})
+	t.Run("tsv", func(t *testing.T) {
+		parseFn, err := getParserForFormat("tsv")
+		check(t, parseFn, err)
+	})
+}
+
+func TestGetParserForDSVFile(t *testing.T) {
+	check := func(t *testing.T, format, content string) {
+		t.Helper()
+
+		parseFn, err := getParserForFormat(format)
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		mapping, err := parseFn(NewTestReader(&content))
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		CheckMapping(t, mapping, [][]string{{"cat, dog", "horse"}})
+	}
+
+	t.Run("dsv;sep=|", func(t *testing.T) {
+		check(t, "dsv;sep=|", "cat, dog | horse")
+	})
+	t.Run("DSV;sep=;", func(t *testing.T) {
+		check(t, "DSV;sep=;", "cat, dog;horse")
+	})
+	t.Run("dsv;sep=:", func(t *testing.T) {
+		check(t, "dsv;sep=:", "cat, dog: horse")
+	})
+	t.Run("dsv;sep=tab", func(t *testing.T) {
+		check(t, "dsv;sep=tab", "cat, dog\thorse")
+	})
+	t.Run("Invalid separator", func(t *testing.T) {
+		for _, format := range []string{"dsv;sep=||", "dsv;sep=\"", "dsv;sep=#"} {
+			_, err := getParserForFormat(format)
+			if err == nil || !stringsx.Contains(err.Error(), "Invalid separator") {
+				t.Errorf("Unexpected error for '%s' (got '%v')", format, err)
+			}
+		}
+	})
+	t.Run("Missing separator", func(t *testing.T) {
+		for _, format := range []string{"dsv", ".dsv", "dsv;sep="} {
+			_, err := getParserForFormat(format)
+			if err == nil || !stringsx.Contains(err.Error(), "sep=") {
+				t.Errorf("Unexpected error for '%s' (got '%v')", format, err)
+			}
+		}
+	})
+}
+
+func TestGetParserForJSONFile(t *testing.T) {
+	check := func(t *testing.T, parseFn parseFunction, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		actual := reflect.ValueOf(parseFn)
+		expected := reflect.ValueOf(json.Parse)
+		if actual.Pointer() != expected.Pointer() {
+			t.Error("The parser function should be the JSON parse function")
+		}
+	}
+
+	t.Run(".json", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".json")
+		check(t, parseFn, err)
+	})
+	t.Run(".JSON", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".JSON")
+		check(t, parseFn, err)
+	})
+	t.Run("json", func(t *testing.T) {
+		parseFn, err := getParserForFormat("json")
+		check(t, parseFn, err)
+	})
+}
+
+func TestGetParserForTOMLFile(t *testing.T) {
+	check := func(t *testing.T, parseFn parseFunction, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		actual := reflect.ValueOf(parseFn)
+		expected := reflect.ValueOf(toml.Parse)
+		if actual.Pointer() != expected.Pointer() {
+			t.Error("The parser function should be the TOML parse function")
+		}
+	}
+
+	t.Run(".toml", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".toml")
+		check(t, parseFn, err)
+	})
+	t.Run(".TOML", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".TOML")
+		check(t, parseFn, err)
+	})
+	t.Run("toml", func(t *testing.T) {
+		parseFn, err := getParserForFormat("toml")
+		check(t, parseFn, err)
+	})
+}
+
+func TestGetParserForYAMLFile(t *testing.T) {
+	check := func(t *testing.T, parseFn parseFunction, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("The error should be nil for this test (got '%s')", err)
+		}
+
+		actual := reflect.ValueOf(parseFn)
+		expected := reflect.ValueOf(yaml.Parse)
+		if actual.Pointer() != expected.Pointer() {
+			t.Error("The parser function should be the YAML parse function")
+		}
+	}
+
+	t.Run(".yaml", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".yaml")
+		check(t, parseFn, err)
+	})
+	t.Run(".yml", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".yml")
+		check(t, parseFn, err)
+	})
+	t.Run(".YML", func(t *testing.T) {
+		parseFn, err := getParserForFormat(".YML")
+		check(t, parseFn, err)
+	})
+	t.Run("yaml", func(t *testing.T) {
+		parseFn, err := getParserForFormat("yaml")
+		check(t, parseFn, err)
+	})
+}
+
 func TestParseReaderNoParser(t *testing.T) {
 	content := "Hello world!"
 
@@ -4,6 +4,7 @@ import (
 	"bufio"
 
 	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
 )
 
 type testingT interface {
@@ -14,30 +15,31 @@ type testingT interface {
 	Helper()
 }
 
-// CheckMapping checks if a mapping is of the correct size and contains the
-// correct values. This is a test helper (i.e. it will call t.Helper()).
+// CheckMapping checks if a list of mappings is of the correct size and contains
+// the correct values in the correct order. This is a test helper (i.e. it will
+// call t.Helper()).
 func CheckMapping(
 	t testingT,
-	mapping map[string]string,
+	mappings []common.Mapping,
 	expected [][]string,
 ) {
 	t.Helper()
 
-	if len(mapping) != len(expected) {
-		t.Fatalf("The mapping size should be %d (got %d)", len(expected), len(mapping))
+	if len(mappings) != len(expected) {
+		t.Fatalf("The mapping size should be %d (got %d)", len(expected), len(mappings))
 	}
 
-	for _, expectedI := range expected {
+	for i, expectedI := range expected {
 		from, to := expectedI[0], expectedI[1]
 
-		actualTo, ok := mapping[from]
-		if !ok {
-			t.Errorf("Missing from-value '%s'", from)
+		actual := mappings[i]
+		if actual.From != from {
+			t.Errorf("Incorrect from value at %d (got '%s')", i, actual.From)
 			continue
 		}
 
-		if actualTo != to {
-			t.Errorf("Incorrect to value for '%s' (got '%s')", from, actualTo)
+		if actual.To != to {
+			t.Errorf("Incorrect to value for '%s' (got '%s')", from, actual.To)
 		}
 	}
 }
@@ -1,12 +1,16 @@
 package testing
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
 
 func TestCheckMapping(t *testing.T) {
 	t.Run("empty mapping and no expectations", func(t *testing.T) {
 		mock := newMockT()
 
-		mapping := make(map[string]string)
+		var mapping []common.Mapping
 		expected := make([][]string, 0)
 		CheckMapping(&mock, mapping, expected)
 
@@ -17,8 +21,7 @@ func TestCheckMapping(t *testing.T) {
 	t.Run("succeeds if mapping matches expected", func(t *testing.T) {
 		mock := newMockT()
 
-		mapping := make(map[string]string)
-		mapping["foo"] = "bar"
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 		expected := make([][]string, 1)
 		expected[0] = []string{"foo", "bar"}
@@ -36,8 +39,7 @@ func TestCheckMapping(t *testing.T) {
 	t.Run("errors if values don't match", func(t *testing.T) {
 		mock := newMockT()
 
-		mapping := make(map[string]string)
-		mapping["foo"] = "bar"
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 		expected := make([][]string, 1)
 		expected[0] = []string{"foo", "baz"}
@@ -55,8 +57,7 @@ func TestCheckMapping(t *testing.T) {
 	t.Run("errors if keys don't match", func(t *testing.T) {
 		mock := newMockT()
 
-		mapping := make(map[string]string)
-		mapping["foo"] = "bar"
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 		expected := make([][]string, 1)
 		expected[0] = []string{"hello", "world"}
@@ -84,7 +85,7 @@ func TestCheckMapping(t *testing.T) {
 			}
 		}()
 
-		mapping := make(map[string]string)
+		var mapping []common.Mapping
 		expected := make([][]string, 1)
 		CheckMapping(&mock, mapping, expected)
 	})
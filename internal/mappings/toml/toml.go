@@ -0,0 +1,291 @@
+package toml
+
+import (
+	"bufio"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/mappings/errors"
+	"github.com/pelletier/go-toml"
+)
+
+// Regular expression to extract the position from an error of the TOML parser.
+var positionErrorExpr = regexp.MustCompile(`^\((\d+), (\d+)\): (.*)$`)
+
+// The fields the meta section may have.
+var metaFields = []string{"name", "version", "description"}
+
+// The tables and fields a file may have at the top level.
+var topLevelFields = []string{"meta", "rule"}
+
+// Create an error with a message, formatted according to the `format`, for the
+// value at `position`.
+func errorAt(position toml.Position, format string, a ...interface{}) error {
+	a = append(a, position.Line, position.Col)
+	return errors.Newf(format+" (on line %d, column %d)", a...)
+}
+
+// Convert an error of the TOML parser into an error that reports the line and
+// column at which it occurred, if known.
+func convertError(err error) error {
+	match := positionErrorExpr.FindStringSubmatch(err.Error())
+	if match == nil {
+		return errors.Newf("Invalid TOML: %s", err)
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	column, _ := strconv.Atoi(match[2])
+	position := toml.Position{Line: line, Col: column}
+	return errorAt(position, "Invalid TOML: %s", match[3])
+}
+
+// Get the value of the `key` in the `tree`, or nil if it is not set.
+func get(tree *toml.Tree, key string) interface{} {
+	return tree.GetPath([]string{key})
+}
+
+// Get the position of the `key` in the `tree`, or the position of the `tree` if
+// the key is not set.
+func positionOf(tree *toml.Tree, key string) toml.Position {
+	if get(tree, key) == nil {
+		return tree.Position()
+	}
+
+	return tree.GetPositionPath([]string{key})
+}
+
+// Check that the keys of the `tree` are all `known`.
+//
+// The error will be set if any key is unknown.
+func checkKeys(tree *toml.Tree, known []string) error {
+	keys := tree.Keys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !common.IsOneOf(key, known) {
+			return errorAt(positionOf(tree, key), "Unknown field '%s'", key)
+		}
+	}
+
+	return nil
+}
+
+// Get the string value of the `key` in the `tree`, or nil if it is not set.
+//
+// The error will be set if the value is not a string.
+func getString(tree *toml.Tree, key string) (*string, error) {
+	value := get(tree, key)
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, errorAt(positionOf(tree, key), "Incorrect format")
+	}
+
+	return &s, nil
+}
+
+// Get the boolean value of the `key` in the `tree`, or nil if it is not set.
+//
+// The error will be set if the value is not a boolean.
+func getBool(tree *toml.Tree, key string) (*bool, error) {
+	value := get(tree, key)
+	if value == nil {
+		return nil, nil
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return nil, errorAt(positionOf(tree, key), "Incorrect format")
+	}
+
+	return &b, nil
+}
+
+// Get the metadata of the `rule`.
+//
+// The error will be set if any metadata has an incorrect type or is not valid.
+func getMetadata(rule *toml.Tree) (metadata common.Metadata, err error) {
+	if metadata.Description, err = getString(rule, "description"); err != nil {
+		return metadata, err
+	}
+
+	if metadata.Severity, err = getString(rule, "severity"); err != nil {
+		return metadata, err
+	}
+
+	if metadata.CaseSensitive, err = getBool(rule, "case-sensitive"); err != nil {
+		return metadata, err
+	}
+
+	if metadata.Enabled, err = getBool(rule, "enabled"); err != nil {
+		return metadata, err
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return metadata, errorAt(positionOf(rule, "severity"), "%s", err)
+	}
+
+	return metadata, nil
+}
+
+// Parse the `[meta]` table of a file. Its values describe the file and are not
+// used otherwise.
+//
+// The error will be set if the table is not valid.
+func parseMeta(tree *toml.Tree) error {
+	meta, ok := get(tree, "meta").(*toml.Tree)
+	if !ok {
+		return errorAt(positionOf(tree, "meta"), "Incorrect format")
+	}
+
+	if err := checkKeys(meta, metaFields); err != nil {
+		return err
+	}
+
+	for _, key := range metaFields {
+		if _, err := getString(meta, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parse the `from` and `to` values of the `rule` into a list of values such
+// that all values but the last are mapped to the last value.
+//
+// The error will be set if either has an incorrect type or is empty.
+func parseValues(rule *toml.Tree) ([][]byte, error) {
+	to, ok := get(rule, "to").(string)
+	if !ok {
+		return nil, errorAt(positionOf(rule, "to"), "Incorrect format")
+	}
+
+	var from []interface{}
+	switch value := get(rule, "from").(type) {
+	case string:
+		from = []interface{}{value}
+	case []interface{}:
+		from = value
+	}
+
+	if len(from) == 0 {
+		return nil, errorAt(positionOf(rule, "from"), "Incorrect format")
+	}
+
+	values := make([][]byte, 0, len(from)+1)
+	for _, value := range append(from, to) {
+		s, ok := value.(string)
+		if !ok {
+			return nil, errorAt(positionOf(rule, "from"), "Incorrect format")
+		}
+
+		values = append(values, []byte(s))
+	}
+
+	values, err := common.TrimValues(values)
+	if err != nil {
+		return nil, errorAt(rule.Position(), "Missing value")
+	}
+
+	return values, nil
+}
+
+// Parse a single `[[rule]]` table into `mappings`.
+//
+// The error will be set if the rule is not valid.
+func parseRule(mappings *common.MappingList, rule *toml.Tree) error {
+	if err := checkKeys(rule, common.RuleFields); err != nil {
+		return err
+	}
+
+	values, err := parseValues(rule)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := getMetadata(rule)
+	if err != nil || !metadata.IsEnabled() {
+		return err
+	}
+
+	mapping := common.Mapping{Line: rule.Position().Line}
+	metadata.ApplyTo(&mapping)
+
+	last := len(values) - 1
+	mapping.To = string(values[last])
+	for _, from := range values[:last] {
+		mapping.From = string(from)
+		mappings.Set(mapping)
+	}
+
+	return nil
+}
+
+// Parse a TOML file into a list of mappings, in the order in which they are
+// defined. Every `[[rule]]` table defines a rule with a `from` value, which is
+// either a string or a list of strings, and a `to` value. A rule may also have
+// a `description`, a `severity` ("error", "warning", or "info"), and may set
+// `case-sensitive` or `enabled`. Rules that are not enabled are omitted. The
+// file may have a `[meta]` table with the `name`, `version`, and `description`
+// of the file. For example:
+//
+//	[meta]
+//	name = "Animals"
+//	version = "1.0.0"
+//
+//	[[rule]]
+//	from = "dog"
+//	to = "cat"
+//
+//	[[rule]]
+//	from = ["canary", "finch"]
+//	to = "parrot"
+//	severity = "info"
+//
+// The error will be set if any error occurred while parsing the TOML file. It
+// reports the line and column at which the error occurred.
+func Parse(reader *bufio.Reader) ([]common.Mapping, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := toml.LoadBytes(content)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	if err := checkKeys(tree, topLevelFields); err != nil {
+		return nil, err
+	}
+
+	if get(tree, "meta") != nil {
+		if err := parseMeta(tree); err != nil {
+			return nil, err
+		}
+	}
+
+	if get(tree, "rule") == nil {
+		return nil, nil
+	}
+
+	rules, ok := get(tree, "rule").([]*toml.Tree)
+	if !ok {
+		return nil, errorAt(positionOf(tree, "rule"), "Incorrect format")
+	}
+
+	var mappings common.MappingList
+	for _, rule := range rules {
+		if err := parseRule(&mappings, rule); err != nil {
+			return mappings.Mappings(), err
+		}
+	}
+
+	return mappings.Mappings(), nil
+}
@@ -0,0 +1,15 @@
+// +build gofuzz
+
+package toml
+
+import (
+	"bufio"
+	"bytes"
+)
+
+func Fuzz(data []byte) int {
+	rawReader := bytes.NewReader(data)
+	bufReader := bufio.NewReader(rawReader)
+	Parse(bufReader)
+	return 0
+}
@@ -0,0 +1,236 @@
+package toml
+
+import (
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+	. "github.com/ericcornelissen/wordrow/internal/mappings/testing"
+)
+
+func TestTomlRules(t *testing.T) {
+	t.Run("Single from value", func(t *testing.T) {
+		toml := "" +
+			"# Animals\n" +
+			"[[rule]]\n" +
+			"from = \"cat\"\n" +
+			"to = \"dog\"\n" +
+			"\n" +
+			"[[rule]]\n" +
+			"from = \"horse\"\n" +
+			"to = \"zebra\"\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}, {"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+
+		expectedLines := []int{2, 6}
+		for i, line := range expectedLines {
+			if mapping[i].Line != line {
+				t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+			}
+		}
+	})
+	t.Run("Many from values", func(t *testing.T) {
+		toml := "" +
+			"[[rule]]\n" +
+			"from = [\"cat\", \"dog\"]\n" +
+			"to = \"horse\"\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "horse"}, {"dog", "horse"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Whitespace and special characters", func(t *testing.T) {
+		toml := "" +
+			"[[rule]]\n" +
+			"from = ' e.g., '\n" +
+			"to = 'for \"example\",'\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"e.g.,", `for "example",`}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestTomlMetadata(t *testing.T) {
+	t.Run("Meta section", func(t *testing.T) {
+		toml := "" +
+			"[meta]\n" +
+			"name = \"Animals\"\n" +
+			"version = \"1.0.0\"\n" +
+			"description = \"\"\"\n" +
+			"Replace animals\n" +
+			"by other animals.\"\"\"\n" +
+			"\n" +
+			"[[rule]]\n" +
+			"from = \"cat\"\n" +
+			"to = \"dog\"\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Rule metadata", func(t *testing.T) {
+		toml := "" +
+			"[[rule]]\n" +
+			"from = \"JS\"\n" +
+			"to = \"JavaScript\"\n" +
+			"description = \"Use the full name\"\n" +
+			"severity = \"warning\"\n" +
+			"case-sensitive = true\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"JS", "JavaScript"}}
+		CheckMapping(t, mapping, expected)
+
+		if !mapping[0].CaseSensitive {
+			t.Error("Mapping should be case sensitive")
+		}
+
+		if mapping[0].Severity != "warning" {
+			t.Errorf("Incorrect severity (got '%s')", mapping[0].Severity)
+		}
+
+		if mapping[0].Description != "Use the full name" {
+			t.Errorf("Incorrect description (got '%s')", mapping[0].Description)
+		}
+	})
+	t.Run("Disabled rules", func(t *testing.T) {
+		toml := "" +
+			"[[rule]]\n" +
+			"from = \"cat\"\n" +
+			"to = \"dog\"\n" +
+			"enabled = false\n" +
+			"\n" +
+			"[[rule]]\n" +
+			"from = \"horse\"\n" +
+			"to = \"zebra\"\n" +
+			"enabled = true\n"
+
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestTomlEmpty(t *testing.T) {
+	for _, toml := range []string{"", "# Comment\n", "[meta]\nname = \"Empty\"\n"} {
+		reader := NewTestReader(&toml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for %q (got '%s')", toml, err)
+		}
+
+		if len(mapping) != 0 {
+			t.Errorf("Unexpected mapping size for %q (got %d)", toml, len(mapping))
+		}
+	}
+}
+
+func TestTomlErrors(t *testing.T) {
+	cases := map[string]struct {
+		toml     string
+		expected string
+	}{
+		"Syntax error": {
+			toml:     "[[rule]]\nfrom = \"cat\"\nto = dog\n",
+			expected: "Invalid TOML",
+		},
+		"Unknown top-level field": {
+			toml:     "[[rules]]\nfrom = \"cat\"\nto = \"dog\"\n",
+			expected: "Unknown field 'rules' (on line 1",
+		},
+		"Unknown meta field": {
+			toml:     "[meta]\nname = \"Animals\"\nauthor = \"me\"\n",
+			expected: "Unknown field 'author' (on line 3",
+		},
+		"Incorrect meta field": {
+			toml:     "[meta]\nversion = 1\n",
+			expected: "Incorrect format (on line 2",
+		},
+		"Meta is not a table": {
+			toml:     "meta = \"Animals\"\n",
+			expected: "Incorrect format (on line 1",
+		},
+		"Rule is not a table": {
+			toml:     "rule = \"cat\"\n",
+			expected: "Incorrect format (on line 1",
+		},
+		"Unknown rule field": {
+			toml:     "[[rule]]\nfrom = \"cat\"\nto = \"dog\"\ncase_sensitive = true\n",
+			expected: "Unknown field 'case_sensitive' (on line 4",
+		},
+		"Incorrect to value": {
+			toml:     "[[rule]]\nfrom = \"cat\"\nto = 42\n",
+			expected: "Incorrect format (on line 3",
+		},
+		"Incorrect from value": {
+			toml:     "[[rule]]\nfrom = [42]\nto = \"dog\"\n",
+			expected: "Incorrect format (on line 2",
+		},
+		"Missing to value": {
+			toml:     "[[rule]]\nfrom = \"cat\"\n",
+			expected: "Incorrect format (on line 1",
+		},
+		"Empty from list": {
+			toml:     "[[rule]]\nfrom = []\nto = \"dog\"\n",
+			expected: "Incorrect format (on line 2",
+		},
+		"Empty value": {
+			toml:     "[[rule]]\nfrom = [\"cat\", \" \"]\nto = \"dog\"\n",
+			expected: "Missing value (on line 1",
+		},
+		"Incorrect metadata": {
+			toml:     "[[rule]]\nfrom = \"cat\"\nto = \"dog\"\nenabled = \"no\"\n",
+			expected: "Incorrect format (on line 4",
+		},
+		"Unknown severity": {
+			toml:     "[[rule]]\nfrom = \"cat\"\nto = \"dog\"\nseverity = \"fatal\"\n",
+			expected: "Unknown severity 'fatal' (on line 4",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			reader := NewTestReader(&c.toml)
+			_, err := Parse(reader)
+			if err == nil {
+				t.Fatal("Error should be set for this test")
+			}
+
+			if !stringsx.Contains(err.Error(), c.expected) {
+				t.Errorf("Incorrect error message (got '%s')", err)
+			}
+		})
+	}
+}
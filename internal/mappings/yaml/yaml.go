@@ -0,0 +1,313 @@
+package yaml
+
+import (
+	"bufio"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/mappings/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Regular expression to extract the line from an error of the YAML decoder.
+var lineErrorExpr = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// The fields a group may have.
+var groupFields = append([]string{"name", "rules"}, common.MetadataFields...)
+
+// The fields the top-level object may have.
+var topLevelFields = []string{"rules", "groups"}
+
+// The rule type represents a single rule.
+type rule struct {
+	common.Metadata `yaml:",inline"`
+
+	// The value(s) to be replaced, either a string or a list of strings.
+	From yaml.Node `yaml:"from"`
+
+	// The value to replace the From value(s) with.
+	To yaml.Node `yaml:"to"`
+}
+
+// The group type represents a named group of rules sharing their metadata.
+type group struct {
+	common.Metadata `yaml:",inline"`
+
+	// The name of the group.
+	Name string `yaml:"name"`
+
+	// The rules of the group.
+	Rules yaml.Node `yaml:"rules"`
+}
+
+// Create an error with a message, formatted according to the `format`, for the
+// value on `line`.
+func errorAt(line int, format string, a ...interface{}) error {
+	return errors.Newf(format+" (on line %d)", append(a, line)...)
+}
+
+// Convert an error of the YAML decoder into an error that reports the line at
+// which it occurred, if known.
+func convertError(err error) error {
+	message := err.Error()
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		message = typeErr.Errors[0]
+	}
+
+	if match := lineErrorExpr.FindStringSubmatch(message); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return errorAt(line, "Invalid YAML: %s", match[2])
+	}
+
+	return errors.Newf("Invalid YAML: %s", stringsx.TrimPrefix(message, "yaml: "))
+}
+
+// Resolve the `node` if it is an alias, otherwise it is returned as is.
+func resolve(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return node.Alias
+	}
+
+	return node
+}
+
+// Check that the keys of the mapping `node` are all `known`.
+//
+// The error will be set if any key is unknown.
+func checkKeys(node *yaml.Node, known []string) error {
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if !common.IsOneOf(key.Value, known) {
+			return errorAt(key.Line, "Unknown field '%s'", key.Value)
+		}
+	}
+
+	return nil
+}
+
+// Get the elements of the sequence `node`. A null value is an empty sequence.
+//
+// The error will be set if the node is not a sequence.
+func elements(node *yaml.Node) ([]*yaml.Node, error) {
+	node = resolve(node)
+	switch {
+	case node.Kind == 0 || node.Tag == "!!null":
+		return nil, nil
+	case node.Kind == yaml.SequenceNode:
+		return node.Content, nil
+	default:
+		return nil, errorAt(node.Line, "Incorrect format")
+	}
+}
+
+// Parse the `from` and `to` values of the rule defined at `line` into a list
+// of values such that all values but the last are mapped to the last value.
+//
+// The error will be set if either has an incorrect type or is empty.
+func parseValues(r *rule, line int) ([][]byte, error) {
+	from, to := resolve(&r.From), resolve(&r.To)
+	if to.Kind != yaml.ScalarNode {
+		return nil, errorAt(line, "Incorrect format")
+	}
+
+	fromValues := []*yaml.Node{from}
+	if from.Kind == yaml.SequenceNode && len(from.Content) > 0 {
+		fromValues = from.Content
+	}
+
+	values := make([][]byte, 0, len(fromValues)+1)
+	for _, value := range append(fromValues, to) {
+		if value = resolve(value); value.Kind != yaml.ScalarNode {
+			return nil, errorAt(line, "Incorrect format")
+		}
+
+		values = append(values, []byte(value.Value))
+	}
+
+	values, err := common.TrimValues(values)
+	if err != nil {
+		return nil, errorAt(line, "Missing value")
+	}
+
+	return values, nil
+}
+
+// Parse the rule `node` into `mappings`, using the `parent` metadata for any
+// metadata the rule does not define.
+//
+// The error will be set if the rule is not valid.
+func parseRule(
+	mappings *common.MappingList,
+	node *yaml.Node,
+	parent common.Metadata,
+) error {
+	node = resolve(node)
+	if node.Kind != yaml.MappingNode {
+		return errorAt(node.Line, "Incorrect format")
+	}
+
+	if err := checkKeys(node, common.RuleFields); err != nil {
+		return err
+	}
+
+	var r rule
+	if err := node.Decode(&r); err != nil {
+		return convertError(err)
+	}
+
+	values, err := parseValues(&r, node.Line)
+	if err != nil {
+		return err
+	}
+
+	metadata := r.Metadata.Inherit(parent)
+	if err := metadata.Validate(); err != nil {
+		return errorAt(node.Line, "%s", err)
+	}
+
+	if !metadata.IsEnabled() {
+		return nil
+	}
+
+	mapping := common.Mapping{Line: node.Line}
+	metadata.ApplyTo(&mapping)
+
+	last := len(values) - 1
+	mapping.To = string(values[last])
+	for _, from := range values[:last] {
+		mapping.From = string(from)
+		mappings.Set(mapping)
+	}
+
+	return nil
+}
+
+// Parse the sequence of rules `node` into `mappings`, using the `parent`
+// metadata for any metadata a rule does not define.
+//
+// The error will be set if any rule is not valid.
+func parseRules(
+	mappings *common.MappingList,
+	node *yaml.Node,
+	parent common.Metadata,
+) error {
+	rules, err := elements(node)
+	if err != nil {
+		return err
+	}
+
+	for _, ruleNode := range rules {
+		if err := parseRule(mappings, ruleNode, parent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parse the sequence of groups `node` into `mappings`.
+//
+// The error will be set if any group, or any rule in a group, is not valid.
+func parseGroups(
+	mappings *common.MappingList,
+	node *yaml.Node,
+) error {
+	groups, err := elements(node)
+	if err != nil {
+		return err
+	}
+
+	for _, groupNode := range groups {
+		groupNode = resolve(groupNode)
+		if groupNode.Kind != yaml.MappingNode {
+			return errorAt(groupNode.Line, "Incorrect format")
+		}
+
+		if err := checkKeys(groupNode, groupFields); err != nil {
+			return err
+		}
+
+		var g group
+		if err := groupNode.Decode(&g); err != nil {
+			return convertError(err)
+		}
+
+		if err := parseRules(mappings, &g.Rules, g.Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parse a YAML file into a list of mappings, in the order in which they are
+// defined. The file contains either a list of rules, or an object with a list
+// of `rules` and a list of `groups`. Every rule has a `from` value, which is
+// either a string or a list of strings, and a `to` value. It may also have a
+// `description`, a `severity` ("error", "warning", or "info"), and may set
+// `case-sensitive` or `enabled`. Rules that are not enabled are omitted. A group
+// has a `name`, a list of `rules`, and may define any metadata of a rule for all
+// of its rules. For example:
+//
+//	rules:
+//	  - from: dog
+//	    to: cat
+//	groups:
+//	  - name: Birds
+//	    severity: info
+//	    rules:
+//	      - from: [canary, finch]
+//	        to: parrot
+//
+// The error will be set if any error occurred while parsing the YAML file. It
+// reports the line at which the error occurred.
+func Parse(reader *bufio.Reader) ([]common.Mapping, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, convertError(err)
+	}
+
+	if document.Kind == 0 {
+		return nil, nil
+	}
+
+	root := resolve(document.Content[0])
+	switch {
+	case root.Tag == "!!null":
+		return nil, nil
+	case root.Kind == yaml.SequenceNode:
+		var mappings common.MappingList
+		err := parseRules(&mappings, root, common.Metadata{})
+		return mappings.Mappings(), err
+	case root.Kind != yaml.MappingNode:
+		return nil, errorAt(root.Line, "Incorrect format")
+	}
+
+	if err := checkKeys(root, topLevelFields); err != nil {
+		return nil, err
+	}
+
+	var mappings common.MappingList
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value == "rules" {
+			err = parseRules(&mappings, value, common.Metadata{})
+		} else {
+			err = parseGroups(&mappings, value)
+		}
+
+		if err != nil {
+			return mappings.Mappings(), err
+		}
+	}
+
+	return mappings.Mappings(), nil
+}
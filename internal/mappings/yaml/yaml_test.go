@@ -0,0 +1,271 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+	. "github.com/ericcornelissen/wordrow/internal/mappings/testing"
+)
+
+func TestYamlRules(t *testing.T) {
+	t.Run("List of rules", func(t *testing.T) {
+		yaml := "" +
+			"# Animals\n" +
+			"- from: cat\n" +
+			"  to: dog\n" +
+			"- from: horse\n" +
+			"  to: zebra\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}, {"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+
+		expectedLines := []int{2, 4}
+		for i, line := range expectedLines {
+			if mapping[i].Line != line {
+				t.Errorf("Incorrect line for '%s' (got %d)", mapping[i].From, mapping[i].Line)
+			}
+		}
+	})
+	t.Run("Object with rules", func(t *testing.T) {
+		yaml := "" +
+			"rules:\n" +
+			"  - from: cat\n" +
+			"    to: dog\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"cat", "dog"}}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Many from values", func(t *testing.T) {
+		yaml := "" +
+			"- from: [cat, dog]\n" +
+			"  to: horse\n" +
+			"- from:\n" +
+			"    - canary\n" +
+			"    - finch\n" +
+			"  to: parrot\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{
+			{"cat", "horse"},
+			{"dog", "horse"},
+			{"canary", "parrot"},
+			{"finch", "parrot"},
+		}
+		CheckMapping(t, mapping, expected)
+	})
+	t.Run("Whitespace and special characters", func(t *testing.T) {
+		yaml := "" +
+			"- from: ' e.g., '\n" +
+			"  to: 'for \"example\",'\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"e.g.,", `for "example",`}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestYamlMetadata(t *testing.T) {
+	t.Run("Rule metadata", func(t *testing.T) {
+		yaml := "" +
+			"- from: JS\n" +
+			"  to: JavaScript\n" +
+			"  severity: warning\n" +
+			"  case-sensitive: true\n" +
+			"  description: |\n" +
+			"    Use the full name\n" +
+			"    of the language.\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"JS", "JavaScript"}}
+		CheckMapping(t, mapping, expected)
+
+		if !mapping[0].CaseSensitive {
+			t.Error("Mapping should be case sensitive")
+		}
+
+		if mapping[0].Severity != "warning" {
+			t.Errorf("Incorrect severity (got '%s')", mapping[0].Severity)
+		}
+
+		expectedDescription := "Use the full name\nof the language."
+		if mapping[0].Description != expectedDescription {
+			t.Errorf("Incorrect description (got '%s')", mapping[0].Description)
+		}
+	})
+	t.Run("Disabled rules", func(t *testing.T) {
+		yaml := "" +
+			"- from: cat\n" +
+			"  to: dog\n" +
+			"  enabled: false\n" +
+			"- from: horse\n" +
+			"  to: zebra\n" +
+			"  enabled: true\n"
+
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for this test (got '%s')", err)
+		}
+
+		expected := [][]string{{"horse", "zebra"}}
+		CheckMapping(t, mapping, expected)
+	})
+}
+
+func TestYamlGroups(t *testing.T) {
+	yaml := "" +
+		"rules:\n" +
+		"  - from: cat\n" +
+		"    to: dog\n" +
+		"groups:\n" +
+		"  - name: Birds\n" +
+		"    severity: info\n" +
+		"    rules:\n" +
+		"      - from: canary\n" +
+		"        to: parrot\n" +
+		"      - from: finch\n" +
+		"        to: robin\n" +
+		"        severity: error\n" +
+		"  - name: Disabled\n" +
+		"    enabled: false\n" +
+		"    rules:\n" +
+		"      - from: horse\n" +
+		"        to: zebra\n" +
+		"  - name: Empty\n" +
+		"    rules:\n"
+
+	reader := NewTestReader(&yaml)
+	mapping, err := Parse(reader)
+	if err != nil {
+		t.Fatalf("Error should be nil for this test (got '%s')", err)
+	}
+
+	expected := [][]string{{"cat", "dog"}, {"canary", "parrot"}, {"finch", "robin"}}
+	CheckMapping(t, mapping, expected)
+
+	expectedSeverities := []string{"", "info", "error"}
+	for i, severity := range expectedSeverities {
+		if mapping[i].Severity != severity {
+			t.Errorf("Incorrect severity for '%s' (got '%s')", mapping[i].From, mapping[i].Severity)
+		}
+	}
+}
+
+func TestYamlEmpty(t *testing.T) {
+	for _, yaml := range []string{"", "[]", "# Comment\n", "rules: []", "groups:"} {
+		reader := NewTestReader(&yaml)
+		mapping, err := Parse(reader)
+		if err != nil {
+			t.Fatalf("Error should be nil for %q (got '%s')", yaml, err)
+		}
+
+		if len(mapping) != 0 {
+			t.Errorf("Unexpected mapping size for %q (got %d)", yaml, len(mapping))
+		}
+	}
+}
+
+func TestYamlErrors(t *testing.T) {
+	cases := map[string]struct {
+		yaml     string
+		expected string
+	}{
+		"Syntax error": {
+			yaml:     "- from: cat\n  to: dog\n - from: horse\n",
+			expected: "Invalid YAML",
+		},
+		"Not a list or object": {
+			yaml:     "cat",
+			expected: "Incorrect format (on line 1)",
+		},
+		"Unknown top-level field": {
+			yaml:     "rules: []\nmappings: []",
+			expected: "Unknown field 'mappings' (on line 2)",
+		},
+		"Unknown rule field": {
+			yaml:     "- from: cat\n  to: dog\n  case_sensitive: true\n",
+			expected: "Unknown field 'case_sensitive' (on line 3)",
+		},
+		"Incorrect to value": {
+			yaml:     "- from: cat\n  to: dog\n- from: horse\n  to: [zebra]\n",
+			expected: "Incorrect format (on line 3)",
+		},
+		"Incorrect from value": {
+			yaml:     "- from: {cat: dog}\n  to: horse\n",
+			expected: "Incorrect format (on line 1)",
+		},
+		"Missing to value": {
+			yaml:     "- from: cat\n",
+			expected: "Incorrect format (on line 1)",
+		},
+		"Empty from list": {
+			yaml:     "- from: []\n  to: dog\n",
+			expected: "Incorrect format (on line 1)",
+		},
+		"Empty value": {
+			yaml:     "- from: [cat, ' ']\n  to: dog\n",
+			expected: "Missing value (on line 1)",
+		},
+		"Incorrect metadata": {
+			yaml:     "- from: cat\n  to: dog\n  enabled: maybe\n",
+			expected: "(on line 3)",
+		},
+		"Unknown severity": {
+			yaml:     "- from: cat\n  to: dog\n  severity: fatal\n",
+			expected: "Unknown severity 'fatal' (on line 1)",
+		},
+		"Rule is not an object": {
+			yaml:     "- cat\n- dog\n",
+			expected: "Incorrect format (on line 1)",
+		},
+		"Group is not an object": {
+			yaml:     "groups:\n  - cat\n",
+			expected: "Incorrect format (on line 2)",
+		},
+		"Incorrect group rules": {
+			yaml:     "groups:\n  - name: Animals\n    rules: cat\n",
+			expected: "Incorrect format (on line 3)",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			reader := NewTestReader(&c.yaml)
+			_, err := Parse(reader)
+			if err == nil {
+				t.Fatal("Error should be set for this test")
+			}
+
+			if !stringsx.Contains(err.Error(), c.expected) {
+				t.Errorf("Incorrect error message (got '%s')", err)
+			}
+		})
+	}
+}
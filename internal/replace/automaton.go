@@ -0,0 +1,204 @@
+package replace
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// The index of the root node of an automaton.
+const root = 0
+
+// The node type represents a single state of an automaton.
+type node struct {
+	// The transitions from this node to other nodes, by byte.
+	next map[byte]int
+
+	// The node to continue from if there is no transition for a byte.
+	fail int
+
+	// The closest node on the chain of fail nodes that has any outputs, or -1 if
+	// there is no such node.
+	link int
+
+	// The indices of the words that end at this node.
+	outputs []int
+}
+
+// The automaton type is an Aho-Corasick automaton that finds which of a set of
+// words appear in a text, ignoring casing, in a single pass over the text.
+type automaton struct {
+	// The nodes of the automaton.
+	nodes []node
+
+	// The number of words of the automaton.
+	size int
+
+	// The length in runes of the longest word of the automaton.
+	longest int
+}
+
+// Fold a rune to the smallest rune that is equal to it under Unicode case
+// folding. Hence, any two runes that are equal ignoring casing fold to the same
+// rune.
+func fold(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+
+		return r
+	}
+
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+
+	return smallest
+}
+
+// Call `fn` for every byte of `s` after folding every rune in `s`. Invalid UTF-8
+// is treated as utf8.RuneError.
+func forEachFoldedByte(s []byte, fn func(b byte)) {
+	var buf [utf8.UTFMax]byte
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			fn(byte(fold(rune(s[i]))))
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(s[i:])
+		n := utf8.EncodeRune(buf[:], fold(r))
+		for _, b := range buf[:n] {
+			fn(b)
+		}
+
+		i += size
+	}
+}
+
+// Get the node reached from node `n` by the byte `b`, following fail nodes if
+// necessary.
+func (a *automaton) step(n int, b byte) int {
+	for {
+		if next, ok := a.nodes[n].next[b]; ok {
+			return next
+		}
+
+		if n == root {
+			return root
+		}
+
+		n = a.nodes[n].fail
+	}
+}
+
+// Add the word `word` as the `i`th word of the automaton.
+func (a *automaton) add(i int, word string) {
+	n := root
+	forEachFoldedByte([]byte(word), func(b byte) {
+		next, ok := a.nodes[n].next[b]
+		if !ok {
+			next = len(a.nodes)
+			a.nodes = append(a.nodes, node{next: make(map[byte]int)})
+			a.nodes[n].next[b] = next
+		}
+
+		n = next
+	})
+
+	a.nodes[n].outputs = append(a.nodes[n].outputs, i)
+}
+
+// Compute the fail nodes and output links of all nodes of the automaton, in
+// breadth-first order.
+func (a *automaton) link() {
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[root].next {
+		a.nodes[child].fail = root
+		a.nodes[child].link = -1
+		queue = append(queue, child)
+	}
+
+	for ; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		for b, child := range a.nodes[n].next {
+			fail := a.step(a.nodes[n].fail, b)
+			a.nodes[child].fail = fail
+
+			a.nodes[child].link = a.nodes[fail].link
+			if len(a.nodes[fail].outputs) > 0 {
+				a.nodes[child].link = fail
+			}
+
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Create a new automaton for the given `words`.
+func newAutomaton(words []string) *automaton {
+	a := &automaton{
+		nodes: []node{{next: make(map[byte]int), link: -1}},
+		size:  len(words),
+	}
+
+	for i, word := range words {
+		a.add(i, word)
+		if n := utf8.RuneCountInString(word); n > a.longest {
+			a.longest = n
+		}
+	}
+
+	a.link()
+	return a
+}
+
+// Find which words of the automaton appear in `s`, ignoring casing. The i-th
+// value of the result is true if and only if the i-th word appears in `s`.
+func (a *automaton) find(s []byte) []bool {
+	found := make([]bool, a.size)
+	a.mark(found, s)
+	return found
+}
+
+// Mark the words of the automaton that appear in `s`, ignoring casing, as found.
+// That is, the i-th value of `found` is set to true if the i-th word appears in
+// `s`, other values are left as is.
+func (a *automaton) mark(found []bool, s []byte) {
+	visited := make([]bool, len(a.nodes))
+
+	n := root
+	forEachFoldedByte(s, func(b byte) {
+		n = a.step(n, b)
+		for m := n; m > root && !visited[m]; m = a.nodes[m].link {
+			visited[m] = true
+			for _, i := range a.nodes[m].outputs {
+				found[i] = true
+			}
+		}
+	})
+}
+
+// Mark the words of the automaton that appear in `s` around the `spans`, i.e.
+// that overlap any of the spans, as found, see mark. The spans are pairs of a
+// starting and ending index in `s`, ordered by their starting index.
+func (a *automaton) markAround(found []bool, s []byte, spans [][2]int) {
+	margin := a.longest * utf8.UTFMax
+
+	for i := 0; i < len(spans); {
+		start, end := maxInt(spans[i][0]-margin, 0), spans[i][1]+margin
+		for i++; i < len(spans) && spans[i][0]-margin <= end; i++ {
+			end = spans[i][1] + margin
+		}
+
+		for start > 0 && !utf8.RuneStart(s[start]) {
+			start--
+		}
+
+		a.mark(found, s[start:minInt(end, len(s))])
+	}
+}
@@ -0,0 +1,116 @@
+package replace
+
+import (
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+func TestFold(t *testing.T) {
+	t.Run("ASCII", func(t *testing.T) {
+		if fold('a') != fold('A') {
+			t.Error("Expected 'a' and 'A' to fold to the same rune")
+		}
+
+		if fold('a') == fold('b') {
+			t.Error("Expected 'a' and 'b' to fold to different runes")
+		}
+
+		if fold('1') != '1' {
+			t.Errorf("Unexpected fold for '1' (got '%c')", fold('1'))
+		}
+	})
+	t.Run("non-ASCII", func(t *testing.T) {
+		if fold('é') != fold('É') {
+			t.Error("Expected 'é' and 'É' to fold to the same rune")
+		}
+
+		if fold('σ') != fold('ς') || fold('σ') != fold('Σ') {
+			t.Error("Expected all sigmas to fold to the same rune")
+		}
+	})
+	t.Run("ASCII and non-ASCII", func(t *testing.T) {
+		if fold('k') != fold('K') {
+			t.Error("Expected 'k' and the Kelvin sign to fold to the same rune")
+		}
+	})
+}
+
+func TestAutomatonFind(t *testing.T) {
+	words := []string{"he", "she", "his", "hers", "Ünïcödé"}
+	a := newAutomaton(words)
+
+	check := func(t *testing.T, s string, expected []bool) {
+		t.Helper()
+
+		found := a.find([]byte(s))
+		for i := range words {
+			if found[i] != expected[i] {
+				t.Errorf("Unexpected result for '%s' in '%s' (got %t)", words[i], s, found[i])
+			}
+		}
+	}
+
+	t.Run("empty string", func(t *testing.T) {
+		check(t, "", []bool{false, false, false, false, false})
+	})
+	t.Run("no words", func(t *testing.T) {
+		check(t, "foobar", []bool{false, false, false, false, false})
+	})
+	t.Run("overlapping words", func(t *testing.T) {
+		check(t, "ushers", []bool{true, true, false, true, false})
+	})
+	t.Run("ignore casing", func(t *testing.T) {
+		check(t, "HIS", []bool{false, false, true, false, false})
+		check(t, "üNÏCÖDÉ", []bool{false, false, false, false, true})
+	})
+	t.Run("invalid UTF-8", func(t *testing.T) {
+		check(t, "\xbfhe\xbf", []bool{true, false, false, false, false})
+	})
+}
+
+func TestAutomatonNoWords(t *testing.T) {
+	a := newAutomaton(nil)
+
+	found := a.find([]byte("Hello world!"))
+	if len(found) != 0 {
+		t.Errorf("Unexpected number of results (got %d)", len(found))
+	}
+}
+
+func TestAutomatonMarkAround(t *testing.T) {
+	words := []string{"cat", "dog", "Ünïcödé"}
+	a := newAutomaton(words)
+
+	padding := stringsx.Repeat(".", 64)
+	s := []byte("a cat, a " + padding + " dog " + padding + " Ünïcödé")
+	check := func(t *testing.T, spans [][2]int, expected []bool) {
+		t.Helper()
+
+		found := make([]bool, len(words))
+		a.markAround(found, s, spans)
+		for i := range words {
+			if found[i] != expected[i] {
+				t.Errorf("Unexpected result for '%s' around %v (got %t)", words[i], spans, found[i])
+			}
+		}
+	}
+
+	t.Run("no spans", func(t *testing.T) {
+		check(t, nil, []bool{false, false, false})
+	})
+	t.Run("overlapping span", func(t *testing.T) {
+		check(t, [][2]int{{3, 4}}, []bool{true, false, false})
+	})
+	t.Run("adjacent span", func(t *testing.T) {
+		check(t, [][2]int{{5, 5}}, []bool{true, false, false})
+	})
+	t.Run("many spans", func(t *testing.T) {
+		end := len(s) - 1
+		check(t, [][2]int{{0, 1}, {2, 3}, {end, end}}, []bool{true, false, true})
+	})
+	t.Run("span in a rune", func(t *testing.T) {
+		end := len(s) - 1
+		check(t, [][2]int{{end - 1, end}}, []bool{false, false, true})
+	})
+}
@@ -0,0 +1,181 @@
+package replace
+
+import (
+	"sort"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
+
+// Change represents a single replacement made by a Replacer. The position of a
+// Change is given in terms of the original string, i.e. the string before any
+// replacement was made.
+type Change struct {
+	// The Mapping that caused the Change.
+	Mapping common.Mapping
+
+	// The starting index of the replaced substring in the original string.
+	Start int
+
+	// The ending index (exclusive) of the replaced substring in the original
+	// string.
+	End int
+
+	// The replaced substring of the original string.
+	Original string
+
+	// The string that replaced the substring of the original string.
+	Replacement string
+}
+
+// A piece represents a substring of a string after zero or more replacements
+// have been made to it, and where that substring came from in the original
+// string.
+type piece struct {
+	// The length of the piece in the current string.
+	length int
+
+	// The starting index of the origin of the piece in the original string.
+	start int
+
+	// The ending index (exclusive) of the origin of the piece in the original
+	// string.
+	end int
+
+	// Flag indicating whether the piece is the result of a replacement. If not,
+	// the piece is equal to its origin.
+	generated bool
+}
+
+// Split an original piece `p` into two pieces at index `i` of the piece.
+func (p piece) split(i int) (head, tail piece) {
+	head = piece{length: i, start: p.start, end: p.start + i}
+	tail = piece{length: p.length - i, start: p.start + i, end: p.end}
+	return head, tail
+}
+
+// The tracker type keeps track of the Changes made to a string while rules are
+// applied to it.
+//
+// If a rule matches text that is (partially) the result of an earlier
+// replacement, the Change covers the original text that the earlier
+// replacement replaced.
+type tracker struct {
+	// The original string.
+	original []byte
+
+	// The pieces that make up the current string, in order.
+	pieces []piece
+
+	// The Changes made so far.
+	changes []Change
+}
+
+// Create a new tracker for the string `s`.
+func newTracker(s []byte) *tracker {
+	return &tracker{
+		original: s,
+		pieces: []piece{
+			{length: len(s), start: 0, end: len(s)},
+		},
+	}
+}
+
+// Update the tracker `t` for the replacements `rs` being applied to the current
+// string `s`. The replacements must be ordered by their starting index, as for
+// applyReplacements.
+func (t *tracker) update(s []byte, rs []replacement) {
+	for _, c := range t.apply(s, rs) {
+		if c.Original != c.Replacement {
+			t.changes = append(t.changes, c)
+		}
+	}
+}
+
+// Get the Change that the replacement `r` would make to the current string `s`,
+// in terms of the original string, without updating the tracker `t`.
+func (t *tracker) locate(s []byte, r replacement) Change {
+	located := tracker{
+		original: t.original,
+		pieces:   append([]piece(nil), t.pieces...),
+	}
+
+	return located.apply(s, []replacement{r})[0]
+}
+
+// Update the pieces of the tracker `t` for the replacements `rs` being applied
+// to the current string `s`, see update. It returns the Change of every
+// replacement, including those that leave the original string as is.
+func (t *tracker) apply(s []byte, rs []replacement) (changes []Change) {
+	var result []piece
+
+	i, pos, lastIndex, mergedStart := 0, 0, 0, 0
+	for _, rr := range rs {
+		start := maxInt(rr.start, lastIndex)
+		end := maxInt(rr.end, start)
+		lastIndex = rr.end
+
+		if len(result) == 0 || start >= pos {
+			for i < len(t.pieces) && pos+t.pieces[i].length <= start {
+				result = append(result, t.pieces[i])
+				pos += t.pieces[i].length
+				i++
+			}
+
+			if i < len(t.pieces) && pos < start && !t.pieces[i].generated {
+				head, tail := t.pieces[i].split(start - pos)
+				result = append(result, head)
+				t.pieces[i] = tail
+				pos = start
+			}
+
+			origin := len(t.original)
+			if i < len(t.pieces) {
+				origin = t.pieces[i].start
+			}
+
+			result = append(result, piece{start: origin, end: origin, generated: true})
+			mergedStart = pos
+		}
+
+		// Merge all pieces covered by the replacement into a single piece. Its
+		// length is corrected for the replacement afterwards.
+		merged := &result[len(result)-1]
+		for i < len(t.pieces) && pos < end {
+			p := t.pieces[i]
+			if pos+p.length > end && !p.generated {
+				head, tail := p.split(end - pos)
+				p = head
+				t.pieces[i] = tail
+			} else {
+				i++
+			}
+
+			merged.length += p.length
+			merged.end = p.end
+			pos += p.length
+		}
+
+		changes = append(changes, Change{
+			Mapping:     rr.rule.mapping,
+			Start:       merged.start,
+			End:         merged.end,
+			Original:    string(t.original[merged.start:merged.end]),
+			Replacement: string(s[mergedStart:start]) + rr.value + string(s[end:pos]),
+		})
+
+		merged.length += len(rr.value) - (end - start)
+	}
+
+	t.pieces = append(result, t.pieces[i:]...)
+	return changes
+}
+
+// Get the Changes tracked by `t`, ordered by their starting index. Changes that
+// start at the same index are ordered by when they were made.
+func (t *tracker) getChanges() []Change {
+	sort.SliceStable(t.changes, func(i, j int) bool {
+		return t.changes[i].Start < t.changes[j].Start
+	})
+
+	return t.changes
+}
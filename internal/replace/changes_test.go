@@ -0,0 +1,288 @@
+package replace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
+
+// CheckChanges checks if the actual changes are equal to the expected changes.
+func checkChanges(t *testing.T, actual, expected []Change) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Unexpected number of changes (got %d)", len(actual))
+	}
+
+	for i, change := range actual {
+		if change != expected[i] {
+			t.Errorf("Unexpected change at %d (got %+v)", i, change)
+		}
+	}
+}
+
+func TestAllChanges(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "cat", To: "dog"}}
+
+		source := []byte("Hello world!")
+		result, changes := New(mapping).AllChanges(source)
+
+		if !bytes.Equal(result, source) {
+			reportIncorrectReplacement(t, source, result)
+		}
+
+		checkChanges(t, changes, nil)
+	})
+	t.Run("one mapping", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "dog", To: "cat"}}
+
+		source := []byte("A dog and a Dog.")
+		result, changes := New(mapping).AllChanges(source)
+
+		expected := []byte("A cat and a Cat.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "dog", Replacement: "cat"},
+			{Mapping: mapping[0], Start: 12, End: 15, Original: "Dog", Replacement: "Cat"},
+		})
+	})
+	t.Run("multiple mappings", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "horse"},
+			{From: "cat", To: "ox"},
+		}
+
+		source := []byte("A cat and a dog.")
+		_, changes := New(mapping).AllChanges(source)
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[1], Start: 2, End: 5, Original: "cat", Replacement: "ox"},
+			{Mapping: mapping[0], Start: 12, End: 15, Original: "dog", Replacement: "horse"},
+		})
+	})
+	t.Run("replacement of a replacement", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "cat", To: "bird"},
+		}
+
+		source := []byte("A dog and a horse.")
+		_, changes := New(mapping).AllChanges(source)
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "dog", Replacement: "cat"},
+			{Mapping: mapping[1], Start: 2, End: 5, Original: "dog", Replacement: "bird"},
+		})
+	})
+	t.Run("replacement of part of a replacement", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "cat", To: "big dog"},
+			{From: "dog house", To: "kennel"},
+		}
+
+		source := []byte("A cat house.")
+		result, changes := New(mapping).AllChanges(source)
+
+		expected := []byte("A big kennel.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "cat", Replacement: "big dog"},
+			{Mapping: mapping[1], Start: 2, End: 11, Original: "cat house", Replacement: "big kennel"},
+		})
+	})
+	t.Run("positions after a replacement", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "a", To: "the"},
+			{From: "dog", To: "cat"},
+		}
+
+		source := []byte("a dog, a dog")
+		_, changes := New(mapping).AllChanges(source)
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 0, End: 1, Original: "a", Replacement: "the"},
+			{Mapping: mapping[1], Start: 2, End: 5, Original: "dog", Replacement: "cat"},
+			{Mapping: mapping[0], Start: 7, End: 8, Original: "a", Replacement: "the"},
+			{Mapping: mapping[1], Start: 9, End: 12, Original: "dog", Replacement: "cat"},
+		})
+	})
+	t.Run("replacement by the same value", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "dog", To: "dog"}}
+
+		source := []byte("A dog.")
+		_, changes := New(mapping).AllChanges(source)
+
+		checkChanges(t, changes, nil)
+	})
+	t.Run("same result as All", func(t *testing.T) {
+		mapping, source := benchmarkInput(1024)
+
+		result, _ := New(mapping).AllChanges(source)
+
+		expected := New(mapping).All(source)
+		if !bytes.Equal(result, expected) {
+			t.Error("Result of AllChanges differs from the result of All")
+		}
+	})
+}
+
+func TestAllSimultaneousChanges(t *testing.T) {
+	t.Run("swap", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "cat", To: "dog"},
+		}
+
+		source := []byte("A dog and a cat.")
+		result, changes := New(mapping).AllSimultaneousChanges(source)
+
+		expected := []byte("A cat and a dog.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "dog", Replacement: "cat"},
+			{Mapping: mapping[1], Start: 12, End: 15, Original: "cat", Replacement: "dog"},
+		})
+	})
+	t.Run("overlapping matches", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "a dog", To: "the bird"},
+		}
+
+		source := []byte("Walk a dog.")
+		_, changes := New(mapping).AllSimultaneousChanges(source)
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[1], Start: 5, End: 10, Original: "a dog", Replacement: "the bird"},
+		})
+	})
+}
+
+func TestAllFunc(t *testing.T) {
+	mapping := []common.Mapping{
+		{From: "cat", To: "dog"},
+		{From: "dog", To: "horse"},
+	}
+	r := New(mapping)
+
+	t.Run("accept all", func(t *testing.T) {
+		source := []byte("A cat and a dog.")
+		result, _ := r.AllFunc(source, func(_ []byte, _ Change) bool {
+			return true
+		})
+
+		expected := r.All(source)
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("reject all", func(t *testing.T) {
+		source := []byte("A cat and a dog.")
+		result, changes := r.AllFunc(source, func(_ []byte, _ Change) bool {
+			return false
+		})
+
+		if !bytes.Equal(result, source) {
+			reportIncorrectReplacement(t, source, result)
+		}
+
+		checkChanges(t, changes, nil)
+	})
+	t.Run("decide per match", func(t *testing.T) {
+		source := []byte("Cat, cat, and cat.")
+
+		var asked []Change
+		result, changes := r.AllFunc(source, func(s []byte, c Change) bool {
+			if !bytes.Equal(s[c.Start:c.End], []byte(c.Original)) {
+				t.Errorf("Change does not match the string (got %+v)", c)
+			}
+
+			asked = append(asked, c)
+			return c.Start != 5
+		})
+
+		expected := []byte("Horse, cat, and horse.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		if len(asked) != 5 {
+			t.Errorf("Unexpected number of decisions (got %d)", len(asked))
+		}
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 0, End: 3, Original: "Cat", Replacement: "Dog"},
+			{Mapping: mapping[1], Start: 0, End: 3, Original: "Cat", Replacement: "Horse"},
+			{Mapping: mapping[0], Start: 14, End: 17, Original: "cat", Replacement: "dog"},
+			{Mapping: mapping[1], Start: 14, End: 17, Original: "cat", Replacement: "horse"},
+		})
+	})
+	t.Run("decide in terms of the original", func(t *testing.T) {
+		source := []byte("A cat.")
+
+		var asked []Change
+		r.AllFunc(source, func(s []byte, c Change) bool {
+			if !bytes.Equal(s, source) {
+				t.Errorf("Unexpected string (got '%s')", s)
+			}
+
+			asked = append(asked, c)
+			return true
+		})
+
+		checkChanges(t, asked, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "cat", Replacement: "dog"},
+			{Mapping: mapping[1], Start: 2, End: 5, Original: "cat", Replacement: "horse"},
+		})
+	})
+}
+
+func TestAllSimultaneousFunc(t *testing.T) {
+	mapping := []common.Mapping{
+		{From: "cat", To: "dog"},
+		{From: "dog", To: "cat"},
+	}
+	r := New(mapping)
+
+	t.Run("decide per match", func(t *testing.T) {
+		source := []byte("A cat, a dog, and a cat.")
+
+		var asked []int
+		result, changes := r.AllSimultaneousFunc(source, func(_ []byte, c Change) bool {
+			asked = append(asked, c.Start)
+			return c.Original != "dog"
+		})
+
+		expected := []byte("A dog, a dog, and a dog.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		expectedAsked := []int{2, 9, 20}
+		if len(asked) != len(expectedAsked) {
+			t.Fatalf("Unexpected number of decisions (got %d)", len(asked))
+		}
+
+		for i, start := range expectedAsked {
+			if asked[i] != start {
+				t.Errorf("Unexpected decision at %d (got %d)", i, asked[i])
+			}
+		}
+
+		checkChanges(t, changes, []Change{
+			{Mapping: mapping[0], Start: 2, End: 5, Original: "cat", Replacement: "dog"},
+			{Mapping: mapping[0], Start: 20, End: 23, Original: "cat", Replacement: "dog"},
+		})
+	})
+}
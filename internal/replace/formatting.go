@@ -3,37 +3,63 @@ package replace
 import (
 	"regexp"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/ericcornelissen/stringsx"
 )
 
+// A Regular Expression character class that matches a single character of a
+// word, i.e. a Unicode letter, mark or number.
+const wordCharClass = `[\p{L}\p{M}\p{N}]`
+
 // A Regular Expression that matches newlines.
 var newlineExpr = regexp.MustCompile(`\r|\n|\r\n`)
 
 // Regular Expression to match the words, and substrings between the words, of a
 // phrase.
-var phraseToWordsExpr = regexp.MustCompile(`([A-z]+)([^A-z]*)`)
+var phraseToWordsExpr = regexp.MustCompile(`([\p{L}\p{M}]+)([^\p{L}\p{M}]*)`)
 
 // A Regular Expression that matches groups of whitespace characters.
 var whitespaceExpr = regexp.MustCompile(`(\s+)`)
 
-// Check if a string starts an uppercase letter.
+// Check if a string starts with an uppercase (or titlecase) letter.
 func startsWithCapital(s string) bool {
-	firstChar := s[0]
-	firstCharRune := rune(firstChar)
-	return unicode.IsUpper(firstCharRune)
+	firstRune, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(firstRune) || unicode.IsTitle(firstRune)
 }
 
 // Convert a string to sentence case. I.e. make the first letter in the string
-// uppercase.
+// titlecase, which is uppercase for most letters.
 func toSentenceCase(s string) string {
-	return stringsx.ToUpper(s[:1]) + s[1:]
+	firstRune, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+
+	return string(unicode.ToTitle(firstRune)) + s[size:]
+}
+
+// Check if a string is all caps. I.e. if it contains at least one uppercase (or
+// titlecase) letter and no lowercase letters.
+func isAllCaps(s string) bool {
+	hasCapital := false
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+
+		if unicode.IsUpper(r) || unicode.IsTitle(r) {
+			hasCapital = true
+		}
+	}
+
+	return hasCapital
 }
 
 // If the `from` string is all caps, it will return `to` as all caps as well.
 // Otherwise, the `to` string is returned unchanged.
 func maintainAllCaps(from, to string) string {
-	if stringsx.ToUpper(from) == from {
+	if isAllCaps(from) {
 		return stringsx.ToUpper(to)
 	}
 
@@ -17,6 +17,26 @@ func TestStartsWithCapital(t *testing.T) {
 			t.Error("Expected result to be true")
 		}
 	})
+	t.Run("empty string", func(t *testing.T) {
+		result := startsWithCapital("")
+
+		if result != false {
+			t.Error("Expected result to be false")
+		}
+	})
+	t.Run("non-ASCII scripts", func(t *testing.T) {
+		for _, s := range []string{"Éclair", "Über", "Привет", "Ωμέγα", "ǅemal"} {
+			if startsWithCapital(s) != true {
+				t.Errorf("Expected result to be true for '%s'", s)
+			}
+		}
+
+		for _, s := range []string{"éclair", "über", "привет", "ωμέγα", "日本"} {
+			if startsWithCapital(s) != false {
+				t.Errorf("Expected result to be false for '%s'", s)
+			}
+		}
+	})
 }
 
 func TestToSentenceCase(t *testing.T) {
@@ -41,6 +61,70 @@ func TestToSentenceCase(t *testing.T) {
 			t.Errorf("Unexpected result (got '%s')", result)
 		}
 	})
+	t.Run("empty string", func(t *testing.T) {
+		result := toSentenceCase("")
+
+		if result != "" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
+	t.Run("multi-byte first letter", func(t *testing.T) {
+		result := toSentenceCase("éclair")
+
+		if result != "Éclair" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+
+		result = toSentenceCase("привет мир")
+
+		if result != "Привет мир" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+
+		result = toSentenceCase("ωμέγα")
+
+		if result != "Ωμέγα" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
+	t.Run("titlecase digraph", func(t *testing.T) {
+		result := toSentenceCase("ǆemal")
+
+		if result != "ǅemal" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
+	t.Run("caseless script", func(t *testing.T) {
+		result := toSentenceCase("日本語")
+
+		if result != "日本語" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
+}
+
+func TestIsAllCaps(t *testing.T) {
+	t.Run("all caps", func(t *testing.T) {
+		for _, s := range []string{"FOO", "ÜBER", "ПРИВЕТ", "ΩΜΈΓΑ", "FOO-BAR 42"} {
+			if isAllCaps(s) != true {
+				t.Errorf("Expected result to be true for '%s'", s)
+			}
+		}
+	})
+	t.Run("not all caps", func(t *testing.T) {
+		for _, s := range []string{"Foo", "über", "ПРИВЕт", "Ωμέγα", "fOO"} {
+			if isAllCaps(s) != false {
+				t.Errorf("Expected result to be false for '%s'", s)
+			}
+		}
+	})
+	t.Run("no cased letters", func(t *testing.T) {
+		for _, s := range []string{"", "42", "日本語", "-"} {
+			if isAllCaps(s) != false {
+				t.Errorf("Expected result to be false for '%s'", s)
+			}
+		}
+	})
 }
 
 func TestMaintainAllCaps(t *testing.T) {
@@ -65,6 +149,26 @@ func TestMaintainAllCaps(t *testing.T) {
 			t.Errorf("Unexpected result (got '%s')", result)
 		}
 	})
+	t.Run("all caps, non-ASCII", func(t *testing.T) {
+		result := maintainAllCaps("ÜBER", "größer")
+
+		if result != "GRÖßER" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+
+		result = maintainAllCaps("ПРИВЕТ", "здравствуй")
+
+		if result != "ЗДРАВСТВУЙ" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
+	t.Run("caseless script", func(t *testing.T) {
+		result := maintainAllCaps("日本", "japan")
+
+		if result != "japan" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
 }
 
 func TestMaintainCapitalization(t *testing.T) {
@@ -128,6 +232,25 @@ func TestMaintainCapitalization(t *testing.T) {
 			t.Errorf("Unexpected result (got '%s')", result)
 		}
 	})
+	t.Run("non-ASCII phrases", func(t *testing.T) {
+		result := maintainCapitalization("Café Crème", "coffee with cream")
+
+		if result != "Coffee With cream" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+
+		result = maintainCapitalization("Добрый день", "привет мир")
+
+		if result != "Привет мир" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+
+		result = maintainCapitalization("καλή Μέρα", "γεια σου")
+
+		if result != "γεια Σου" {
+			t.Errorf("Unexpected result (got '%s')", result)
+		}
+	})
 	t.Run("capitalized phrases, short to long", func(t *testing.T) {
 		result := maintainCapitalization("Lorem Ipsum", "dolor sit amet")
 
@@ -32,15 +32,6 @@ func checkMatch(t *testing.T, actualMatch, expectedMatch *match) {
 	}
 }
 
-// Drain empties a channel and returns all values as a slice.
-func drain(ch chan *match) (matches []match) {
-	for match := range ch {
-		matches = append(matches, *match)
-	}
-
-	return matches
-}
-
 // ReportIncorrectReplacement pretty prints an error by the replacement
 // functionality.
 func reportIncorrectReplacement(t *testing.T, expected, actual []byte) {
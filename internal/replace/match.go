@@ -56,12 +56,19 @@ func removeAffixNotation(s string) string {
 	return s
 }
 
+// Get string `s` as a literal string, i.e. without any *wordrow* specific
+// syntax.
+func toLiteralString(s string) (literalString string) {
+	literalString = removeAffixNotation(s)
+	literalString = stringsx.ReplaceAll(literalString, `\\`, `\`)
+	literalString = stringsx.ReplaceAll(literalString, `\-`, `-`)
+	return literalString
+}
+
 // Get string `s` as a safe regular expression (escaping special characters) as
 // well as removing any *wordrow* specific syntax.
 func toSafeString(s string) (safeString string) {
-	safeString = removeAffixNotation(s)
-	safeString = stringsx.ReplaceAll(safeString, `\\`, `\`)
-	safeString = stringsx.ReplaceAll(safeString, `\-`, `-`)
+	safeString = toLiteralString(s)
 	safeString = regexp.QuoteMeta(safeString)
 	return whitespaceExpr.ReplaceAllString(safeString, `\s+`)
 }
@@ -99,24 +106,62 @@ func indicesToMatch(s []byte, indices []int) *match {
 	}
 }
 
-// Find all matches of a `query` string in a target string `s`.
+// The query type represents a compiled query string, i.e. the string of a
+// Mapping that should be found in a text.
+type query struct {
+	// The query string as it appears in the Mapping.
+	raw string
+
+	// The Regular Expression that matches the query.
+	expr *regexp.Regexp
+}
+
+// Compile a `raw` query string into a query. The query ignores casing unless
+// `caseSensitive` is true.
 //
 // Note that non-UTF8 characters are not allowed, if any non-UTF characters are
 // detected the function will panic.
-func matches(s []byte, query string) chan *match {
-	ch := make(chan *match)
-	go func() {
-		defer close(ch)
-
-		safeQuery := toSafeString(query)
-		rawExpr := fmt.Sprintf(`(?i)([A-z0-9]*)(%s)([A-z0-9]*)`, safeQuery)
-		expr := regexp.MustCompile(rawExpr)
-		for _, indices := range expr.FindAllSubmatchIndex(s, -1) {
-			if m := indicesToMatch(s, indices); isValidFor(m, query) {
-				ch <- m
-			}
+func compileQuery(raw string, caseSensitive bool) *query {
+	flags := "(?i)"
+	if caseSensitive {
+		flags = ""
+	}
+
+	safeQuery := toSafeString(raw)
+	rawExpr := fmt.Sprintf(
+		`%s(%s*)(%s)(%s*)`,
+		flags,
+		wordCharClass,
+		safeQuery,
+		wordCharClass,
+	)
+
+	return &query{
+		raw:  raw,
+		expr: regexp.MustCompile(rawExpr),
+	}
+}
+
+// Find all matches of the query `q` in a target string `s`.
+func (q *query) matches(s []byte) (ms []*match) {
+	for _, indices := range q.expr.FindAllSubmatchIndex(s, -1) {
+		if m := indicesToMatch(s, indices); isValidFor(m, q.raw) {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms
+}
+
+// Get the longest word of the query `q`. Ignoring casing, this word is part of
+// every match of the query.
+func (q *query) longestWord() string {
+	var longest string
+	for _, word := range whitespaceExpr.Split(toLiteralString(q.raw), -1) {
+		if len(word) > len(longest) {
+			longest = word
 		}
-	}()
+	}
 
-	return ch
+	return longest
 }
@@ -597,29 +597,31 @@ func TestToSafeString(t *testing.T) {
 func TestMatchesFindNothing(t *testing.T) {
 	s := []byte("hello world!")
 	t.Run("not at all", func(t *testing.T) {
-		ch := matches(s, "bar")
-		result := drain(ch)
+		result := compileQuery("bar", false).matches(s)
 		if len(result) > 0 {
 			t.Fatalf("Expected no matches (got %d)", len(result))
 		}
 	})
 	t.Run("present, but with prefix", func(t *testing.T) {
-		ch := matches(s, "ello")
-		result := drain(ch)
+		result := compileQuery("ello", false).matches(s)
 		if len(result) > 0 {
 			t.Fatalf("Expected no matches (got %d)", len(result))
 		}
 	})
 	t.Run("present, but with suffix", func(t *testing.T) {
-		ch := matches(s, "hell")
-		result := drain(ch)
+		result := compileQuery("hell", false).matches(s)
 		if len(result) > 0 {
 			t.Fatalf("Expected no matches (got %d)", len(result))
 		}
 	})
 	t.Run("present, but with prefix & suffix", func(t *testing.T) {
-		ch := matches(s, "ell")
-		result := drain(ch)
+		result := compileQuery("ell", false).matches(s)
+		if len(result) > 0 {
+			t.Fatalf("Expected no matches (got %d)", len(result))
+		}
+	})
+	t.Run("present, but with different casing", func(t *testing.T) {
+		result := compileQuery("Hello", true).matches(s)
 		if len(result) > 0 {
 			t.Fatalf("Expected no matches (got %d)", len(result))
 		}
@@ -629,8 +631,7 @@ func TestMatchesFindNothing(t *testing.T) {
 func TestMatchesFindSomething(t *testing.T) {
 	s := []byte("hello world!")
 	t.Run("match without prefix or suffix", func(t *testing.T) {
-		ch := matches(s, "hello")
-		result := drain(ch)
+		result := compileQuery("hello", false).matches(s)
 		if len(result) != 1 {
 			t.Fatalf("Expected one match (got %d)", len(result))
 		}
@@ -645,11 +646,10 @@ func TestMatchesFindSomething(t *testing.T) {
 			end:    5,
 		}
 
-		checkMatch(t, &actualMatch, &expectedMatch)
+		checkMatch(t, actualMatch, &expectedMatch)
 	})
 	t.Run("match with prefix", func(t *testing.T) {
-		ch := matches(s, "-ello")
-		result := drain(ch)
+		result := compileQuery("-ello", false).matches(s)
 		if len(result) != 1 {
 			t.Fatalf("Expected one match (got %d)", len(result))
 		}
@@ -664,11 +664,10 @@ func TestMatchesFindSomething(t *testing.T) {
 			end:    5,
 		}
 
-		checkMatch(t, &actualMatch, &expectedMatch)
+		checkMatch(t, actualMatch, &expectedMatch)
 	})
 	t.Run("match with suffix", func(t *testing.T) {
-		ch := matches(s, "hell-")
-		result := drain(ch)
+		result := compileQuery("hell-", false).matches(s)
 		if len(result) != 1 {
 			t.Fatalf("Expected one match (got %d)", len(result))
 		}
@@ -683,11 +682,10 @@ func TestMatchesFindSomething(t *testing.T) {
 			end:    5,
 		}
 
-		checkMatch(t, &actualMatch, &expectedMatch)
+		checkMatch(t, actualMatch, &expectedMatch)
 	})
 	t.Run("match with prefix & suffix", func(t *testing.T) {
-		ch := matches(s, "-ell-")
-		result := drain(ch)
+		result := compileQuery("-ell-", false).matches(s)
 		if len(result) != 1 {
 			t.Fatalf("Expected one match (got %d)", len(result))
 		}
@@ -702,6 +700,38 @@ func TestMatchesFindSomething(t *testing.T) {
 			end:    5,
 		}
 
-		checkMatch(t, &actualMatch, &expectedMatch)
+		checkMatch(t, actualMatch, &expectedMatch)
+	})
+}
+
+func TestQueryLongestWord(t *testing.T) {
+	t.Run("single word", func(t *testing.T) {
+		word := compileQuery("foobar", false).longestWord()
+		if word != "foobar" {
+			t.Errorf("Unexpected longest word (got '%s')", word)
+		}
+	})
+	t.Run("multiple words", func(t *testing.T) {
+		word := compileQuery("a dog  house", false).longestWord()
+		if word != "house" {
+			t.Errorf("Unexpected longest word (got '%s')", word)
+		}
+	})
+	t.Run("affix notation", func(t *testing.T) {
+		word := compileQuery("- dogs", false).longestWord()
+		if word != "dogs" {
+			t.Errorf("Unexpected longest word (got '%s')", word)
+		}
+
+		word = compileQuery("-ize", false).longestWord()
+		if word != "ize" {
+			t.Errorf("Unexpected longest word (got '%s')", word)
+		}
+	})
+	t.Run("escaped hyphen", func(t *testing.T) {
+		word := compileQuery(`world\-`, false).longestWord()
+		if word != "world-" {
+			t.Errorf("Unexpected longest word (got '%s')", word)
+		}
 	})
 }
@@ -1,12 +1,34 @@
 /*
 Package replace provides a function to smart replace strings in plaintext. To
-this end it provides one function that accepts a string and a mapping and
-returns the string with all words of the mapping replaced.
+this end it provides a function that accepts a string and a list of mappings
+and returns the string with all words of the mappings replaced.
 
-	var s string
-	var m map[string]string
+	var s []byte
+	var m []common.Mapping
 	All(s, m)
 
+The mappings are applied in order, so the output for a given input and list of
+mappings is always the same. Alternatively, all mappings can be applied at once
+so that the output of one mapping is never affected by another mapping.
+
+	AllSimultaneous(s, m)
+
+To replace words in many strings using the same mappings, the mappings can be
+compiled into a Replacer once and used for every string.
+
+	r := New(m)
+	r.All(s)
+
+To replace words in content that is too large to hold in memory, a Replacer can
+also read the content from an io.Reader and write the result to an io.Writer.
+
+	r.Stream(w, reader)
+
+A Replacer can be used as a transform.Transformer as well, to combine it with
+other transformations.
+
+	transform.NewReader(reader, r.Transformer())
+
 The replacement will do some clever things to maintain the formatting of the
 original text. Namely:
 
@@ -17,18 +39,13 @@ package replace
 
 import (
 	"bytes"
+	"sort"
 
 	"github.com/ericcornelissen/stringsx"
-	"github.com/ericcornelissen/wordrow/internal/logger"
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/errors"
 )
 
-// Mapping is a utility struct representing a single mapping `from` one string
-// `to` another.
-type mapping struct {
-	from string
-	to   string
-}
-
 // Get the replacement string including prefix/suffix given the match `m`.
 func getReplacement(m *match, s string) string {
 	keepPrefix, keepSuffix := detectAffix(s)
@@ -45,50 +62,328 @@ func getReplacement(m *match, s string) string {
 	return replacement
 }
 
-// Replace all instances of `from` by `to` in `s`.
-func replaceOne(s []byte, m *mapping) []byte {
+// A replacement represents the replacement of a substring of a text by another
+// string.
+type replacement struct {
+	// The starting index of the substring to replace.
+	start int
+
+	// The ending index of the substring to replace.
+	end int
+
+	// The string to replace the substring with.
+	value string
+
+	// The rule that the replacement is for.
+	rule *rule
+}
+
+// The rule type represents a compiled Mapping.
+type rule struct {
+	// The Mapping the rule is compiled from.
+	mapping common.Mapping
+
+	// The query that matches the strings to replace.
+	query *query
+}
+
+// Find all replacements for the rule `r` in `s`.
+func (r *rule) replacements(s []byte) (rs []replacement) {
+	for _, match := range r.query.matches(s) {
+		value, offset := getReplacement(match, r.mapping.To), 0
+		if r.mapping.CaseSensitive {
+			value, offset = maintainWhitespace(string(match.full), value)
+		} else {
+			value, offset = maintainFormatting(string(match.full), value)
+		}
+
+		// The offset may carry a newline over past the end of `s`.
+		rs = append(rs, replacement{
+			start: match.start,
+			end:   minInt(match.end+offset, len(s)),
+			value: value,
+			rule:  r,
+		})
+	}
+
+	return rs
+}
+
+// Apply all replacements `rs` to `s`. The replacements must be ordered by their
+// starting index.
+func applyReplacements(s []byte, rs []replacement) []byte {
+	s, _ = applyReplacementsSpans(s, rs)
+	return s
+}
+
+// Apply all replacements `rs` to `s`, like applyReplacements. It also returns
+// the span of every replacement value in the result, as a pair of its starting
+// and ending index.
+func applyReplacementsSpans(s []byte, rs []replacement) ([]byte, [][2]int) {
 	var bb bytes.Buffer
 
+	spans := make([][2]int, len(rs))
 	lastIndex := 0
-	for match := range matches(s, m.from) {
-		replacement := getReplacement(match, m.to)
-		replacement, offset := maintainFormatting(string(match.full), replacement)
-
-		bb.Write(s[lastIndex:maxInt(match.start, lastIndex)])
-		bb.WriteString(replacement)
-		lastIndex = match.end + offset
+	for i, r := range rs {
+		bb.Write(s[lastIndex:maxInt(r.start, lastIndex)])
+		spans[i][0] = bb.Len()
+		bb.WriteString(r.value)
+		spans[i][1] = bb.Len()
+		lastIndex = r.end
 	}
 
 	if lastIndex < len(s) {
 		bb.Write(s[lastIndex:])
 	}
 
-	return bb.Bytes()
+	return bb.Bytes(), spans
+}
+
+// Select the replacements from `rs` that do not overlap. If two replacements
+// overlap the leftmost one is selected. If they start at the same index the
+// longest one is selected. If they are equally long the first one in `rs` is
+// selected. The selected replacements are ordered by their starting index.
+func selectNonOverlapping(rs []replacement) (selected []replacement) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		if rs[i].start != rs[j].start {
+			return rs[i].start < rs[j].start
+		}
+
+		return (rs[i].end - rs[i].start) > (rs[j].end - rs[j].start)
+	})
+
+	lastIndex := 0
+	for _, r := range rs {
+		if r.start >= lastIndex {
+			selected = append(selected, r)
+			lastIndex = r.end
+		}
+	}
+
+	return selected
 }
 
-// Replace all instances of `from` by `to` defined b `m` in `s`, or return the
-// original string if the mapping is invalid.
-func safeReplaceOne(s []byte, m *mapping) []byte {
-	if !stringsx.IsValidUTF8(m.from) {
-		logger.Warningf("Invalid character in mapping '%s'", m.from)
-		return s
+// A Decider is a function that decides whether a replacement is made. The
+// replacement is described by the Change `c` in terms of the original string
+// `s`, like the Changes made by a Replacer. If other replacements have been made
+// before, the Change covers the original text they replaced.
+type Decider func(s []byte, c Change) bool
+
+// Select the replacements from `rs` in `s` for which `decide` returns true. The
+// replacements are described to `decide` in terms of the original string as
+// tracked by `t`. Replacements that would not change `s` are always selected. If
+// `decide` is nil all replacements are selected.
+func selectDecided(
+	s []byte,
+	rs []replacement,
+	t *tracker,
+	decide Decider,
+) (selected []replacement) {
+	if decide == nil {
+		return rs
 	}
 
-	cleanFrom := stringsx.TrimSpace(removeAffixNotation(m.from))
-	cleanTo := stringsx.TrimSpace(removeAffixNotation(m.to))
+	for _, r := range rs {
+		if string(s[r.start:r.end]) == r.value || decide(t.original, t.locate(s, r)) {
+			selected = append(selected, r)
+		}
+	}
+
+	return selected
+}
+
+// ValidateMapping checks whether the mapping `m` can be used to replace
+// strings.
+//
+// The error will be set if it cannot, describing why not.
+func ValidateMapping(m common.Mapping) error {
+	if !stringsx.IsValidUTF8(m.From) {
+		return errors.Newf("Invalid character in mapping '%s'", m.From)
+	}
+
+	cleanFrom := stringsx.TrimSpace(removeAffixNotation(m.From))
+	cleanTo := stringsx.TrimSpace(removeAffixNotation(m.To))
 	if stringsx.IsEmpty(cleanFrom) || stringsx.IsEmpty(cleanTo) {
-		logger.Warningf("Invalid mapping value '%s,%s'", m.from, m.to)
-		return s
+		return errors.Newf("Invalid mapping value '%s,%s'", m.From, m.To)
+	}
+
+	return nil
+}
+
+// Replacer is a compiled list of mappings that can be used to replace words in
+// any number of strings. A Replacer is safe for concurrent use.
+type Replacer struct {
+	// The rules of the Replacer, in order.
+	rules []*rule
+
+	// The automaton to find which rules may match a string. The i-th word of the
+	// automaton is a word that is part of every match of the i-th rule.
+	automaton *automaton
+
+	// The window used when streaming, see Stream.
+	window int
+}
+
+// New compiles the mappings `m` into a Replacer. Mappings that are invalid, see
+// ValidateMapping, are omitted from the Replacer.
+func New(m []common.Mapping) *Replacer {
+	rules := make([]*rule, 0, len(m))
+	words := make([]string, 0, len(m))
+	for i := range m {
+		if ValidateMapping(m[i]) != nil {
+			continue
+		}
+
+		query := compileQuery(m[i].From, m[i].CaseSensitive)
+		r := &rule{mapping: m[i], query: query}
+		rules = append(rules, r)
+		words = append(words, r.query.longestWord())
 	}
 
-	return replaceOne(s, m)
+	return &Replacer{
+		rules:     rules,
+		automaton: newAutomaton(words),
+		window:    getWindow(rules),
+	}
+}
+
+// Find the rules of `r` that may match in `s`. The i-th value of the result is
+// false only if the i-th rule has no match in `s`.
+func (r *Replacer) candidates(s []byte) []bool {
+	return r.automaton.find(s)
 }
 
-// All replaces substrings of `s` according to the mapping defined by `m`.
-func All(s []byte, m map[string]string) []byte {
-	for from, to := range m {
-		s = safeReplaceOne(s, &mapping{from: from, to: to})
+// Replace substrings of `s` according to the rules of `r`, one after the other.
+// Only the replacements for which `decide` returns true are made. If `t` is not
+// nil, the replacements are tracked by `t`, which is required if `decide` is.
+//
+// The candidates are found in a single pass over `s`. After the replacements of
+// a rule are applied only the text around the replacement values is scanned
+// again, as that is the only place a rule can match that did not before. Rules
+// whose matches were all replaced may remain candidates, which is harmless.
+func (r *Replacer) all(s []byte, t *tracker, decide Decider) []byte {
+	candidates := r.candidates(s)
+	for i, rule := range r.rules {
+		if !candidates[i] {
+			continue
+		}
+
+		rs := selectDecided(s, rule.replacements(s), t, decide)
+		if len(rs) == 0 {
+			continue
+		}
+
+		if t != nil {
+			t.update(s, rs)
+		}
+
+		var spans [][2]int
+		s, spans = applyReplacementsSpans(s, rs)
+		r.automaton.markAround(candidates, s, spans)
 	}
 
 	return s
 }
+
+// Replace substrings of `s` according to the rules of `r`, all at once. Only the
+// replacements for which `decide` returns true are made. If `t` is not nil, the
+// replacements are tracked by `t`, which is required if `decide` is.
+func (r *Replacer) allSimultaneous(
+	s []byte,
+	t *tracker,
+	decide Decider,
+) []byte {
+	var rs []replacement
+
+	candidates := r.candidates(s)
+	for i, rule := range r.rules {
+		if candidates[i] {
+			rs = append(rs, rule.replacements(s)...)
+		}
+	}
+
+	rs = selectDecided(s, selectNonOverlapping(rs), t, decide)
+	if t != nil {
+		t.update(s, rs)
+	}
+
+	return applyReplacements(s, rs)
+}
+
+// All replaces substrings of `s` according to the rules of the Replacer. The
+// rules are applied one after the other, in order. Hence, the output of one
+// rule is the input for the next.
+func (r *Replacer) All(s []byte) []byte {
+	return r.all(s, nil, nil)
+}
+
+// AllChanges is like All but also returns the Changes made to `s`, ordered by
+// their position in `s`.
+func (r *Replacer) AllChanges(s []byte) ([]byte, []Change) {
+	t := newTracker(s)
+	return r.all(s, t, nil), t.getChanges()
+}
+
+// AllFunc is like AllChanges but only makes the replacements for which `decide`
+// returns true. The replacements are decided on rule by rule, and for each rule
+// in order of their position.
+func (r *Replacer) AllFunc(s []byte, decide Decider) ([]byte, []Change) {
+	t := newTracker(s)
+	return r.all(s, t, decide), t.getChanges()
+}
+
+// AllSimultaneous replaces substrings of `s` according to the rules of the
+// Replacer. Unlike All, every rule is matched against the original `s` and all
+// replacements are made at once. Hence, the output of one rule is never the
+// input for another.
+//
+// If matches of different rules overlap the leftmost match is replaced. If
+// they start at the same position the longest match is replaced. If they are
+// equally long, the match of the rule that comes first is replaced.
+func (r *Replacer) AllSimultaneous(s []byte) []byte {
+	return r.allSimultaneous(s, nil, nil)
+}
+
+// AllSimultaneousChanges is like AllSimultaneous but also returns the Changes
+// made to `s`, ordered by their position in `s`.
+func (r *Replacer) AllSimultaneousChanges(s []byte) ([]byte, []Change) {
+	t := newTracker(s)
+	return r.allSimultaneous(s, t, nil), t.getChanges()
+}
+
+// AllSimultaneousFunc is like AllSimultaneousChanges but only makes the
+// replacements for which `decide` returns true. The replacements are decided on
+// in order of their position. If a replacement is rejected, replacements that
+// overlap with it are not made either.
+func (r *Replacer) AllSimultaneousFunc(
+	s []byte,
+	decide Decider,
+) ([]byte, []Change) {
+	t := newTracker(s)
+	return r.allSimultaneous(s, t, decide), t.getChanges()
+}
+
+// All replaces substrings of `s` according to the mappings defined by `m`. The
+// mappings are applied one after the other, in order. Hence, the output of one
+// mapping is the input for the next.
+//
+// To replace substrings in many strings using the same mappings, use New to
+// compile the mappings once instead.
+func All(s []byte, m []common.Mapping) []byte {
+	return New(m).All(s)
+}
+
+// AllSimultaneous replaces substrings of `s` according to the mappings defined
+// by `m`. Unlike All, every mapping is matched against the original `s` and all
+// replacements are made at once. Hence, the output of one mapping is never the
+// input for another.
+//
+// If matches of different mappings overlap the leftmost match is replaced. If
+// they start at the same position the longest match is replaced. If they are
+// equally long, the match of the mapping that comes first in `m` is replaced.
+//
+// To replace substrings in many strings using the same mappings, use New to
+// compile the mappings once instead.
+func AllSimultaneous(s []byte, m []common.Mapping) []byte {
+	return New(m).AllSimultaneous(s)
+}
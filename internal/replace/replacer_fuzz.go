@@ -2,7 +2,12 @@
 
 package replace
 
-import "github.com/ericcornelissen/stringsx"
+import (
+	"bytes"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
 
 func FuzzReplaceAll(data []byte) int {
 	lines := stringsx.Split(string(data), "\n")
@@ -15,7 +20,7 @@ func FuzzReplaceAll(data []byte) int {
 		return -1
 	}
 
-	mapping := make(map[string]string, 1)
+	mapping := make([]common.Mapping, 0, len(mappings)/2)
 	for i := 0; i < len(mappings); i += 2 {
 		from := stringsx.TrimSpace(mappings[i])
 
@@ -24,12 +29,12 @@ func FuzzReplaceAll(data []byte) int {
 			to = stringsx.TrimSpace(mappings[i+1])
 		}
 
-		mapping[from] = to
+		mapping = append(mapping, common.Mapping{From: from, To: to})
 	}
 
-	s := stringsx.Join(lines[1:], "\n")
+	s := []byte(stringsx.Join(lines[1:], "\n"))
 	result := All(s, mapping)
-	if result != s {
+	if !bytes.Equal(result, s) {
 		return 1
 	}
 
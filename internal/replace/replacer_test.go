@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
 )
 
 func ExampleReplaceAll() {
-	mapping := make(map[string]string)
-	mapping["hello"] = "hey"
-	mapping["world"] = "planet"
+	mapping := []common.Mapping{
+		{From: "hello", To: "hey"},
+		{From: "world", To: "planet"},
+	}
 
 	s := []byte("Hello world!")
 	out := All(s, mapping)
@@ -17,8 +20,24 @@ func ExampleReplaceAll() {
 	// Output: Hey planet!
 }
 
+func ExampleReplacer() {
+	mapping := []common.Mapping{
+		{From: "hello", To: "hey"},
+		{From: "world", To: "planet"},
+	}
+
+	r := New(mapping)
+	for _, s := range []string{"Hello world!", "Goodbye world!"} {
+		out := r.All([]byte(s))
+		fmt.Println(string(out))
+	}
+	// Output:
+	// Hey planet!
+	// Goodbye planet!
+}
+
 func TestReplaceEmptyString(t *testing.T) {
-	mapping := make(map[string]string)
+	var mapping []common.Mapping
 
 	source := []byte("")
 	result := All(source, mapping)
@@ -29,7 +48,7 @@ func TestReplaceEmptyString(t *testing.T) {
 }
 
 func TestReplaceEmptyMapping(t *testing.T) {
-	mapping := make(map[string]string)
+	var mapping []common.Mapping
 
 	source := []byte("Hello world!")
 	result := All(source, mapping)
@@ -42,8 +61,7 @@ func TestReplaceEmptyMapping(t *testing.T) {
 func TestReplaceOneWordInMapping(t *testing.T) {
 	from, to := "foo", "bar"
 
-	mapping := make(map[string]string)
-	mapping[from] = to
+	mapping := []common.Mapping{{From: from, To: to}}
 
 	t.Run("source is 'from' in the Mapping", func(t *testing.T) {
 		source := []byte(from)
@@ -88,9 +106,10 @@ func TestReplaceOneWordInMapping(t *testing.T) {
 }
 
 func TestReplaceMultipleWordsInMapping(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["foo"] = "bar"
-	mapping["color"] = "colour"
+	mapping := []common.Mapping{
+		{From: "foo", To: "bar"},
+		{From: "color", To: "colour"},
+	}
 
 	t.Run("All words", func(t *testing.T) {
 		source := []byte("A foo is a creature in this world. It can change its color.")
@@ -112,12 +131,58 @@ func TestReplaceMultipleWordsInMapping(t *testing.T) {
 	})
 }
 
+func TestReplaceMappingsInOrder(t *testing.T) {
+	t.Run("swap back", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "cat", To: "dog"},
+		}
+
+		source := []byte("I have a dog, but you have a small doggy.")
+		result := All(source, mapping)
+
+		expected := []byte("I have a dog, but you have a small doggy.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("swapped order", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "cat", To: "dog"},
+			{From: "dog", To: "cat"},
+		}
+
+		source := []byte("I have a dog, but you have a small doggy.")
+		result := All(source, mapping)
+
+		expected := []byte("I have a cat, but you have a small doggy.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("overlapping phrases", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "a dog", To: "an amazing dog"},
+			{From: "dog", To: "cat"},
+		}
+
+		source := []byte("I have a dog.")
+		expected := []byte("I have an amazing cat.")
+		for i := 0; i < 100; i++ {
+			result := All(source, mapping)
+			if !bytes.Equal(result, expected) {
+				reportIncorrectReplacement(t, expected, result)
+				break
+			}
+		}
+	})
+}
+
 func TestReplaceWhitespaceInPhrase(t *testing.T) {
 	t.Run("single space", func(t *testing.T) {
 		from, to := "foo bar", "foobar"
 
-		mapping := make(map[string]string)
-		mapping[from] = to
+		mapping := []common.Mapping{{From: from, To: to}}
 
 		source := []byte(from)
 		result := All(source, mapping)
@@ -134,8 +199,7 @@ func TestReplaceWhitespaceInPhrase(t *testing.T) {
 	t.Run("two spaces", func(t *testing.T) {
 		from, to := "a  dog", "an amazing dog"
 
-		mapping := make(map[string]string)
-		mapping[from] = to
+		mapping := []common.Mapping{{From: from, To: to}}
 
 		source := []byte(from)
 		result := All(source, mapping)
@@ -152,8 +216,7 @@ func TestReplaceWhitespaceInPhrase(t *testing.T) {
 }
 
 func TestReplaceIgnoreCapitalizationInMapping(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["Foo"] = "Bar"
+	mapping := []common.Mapping{{From: "Foo", To: "Bar"}}
 
 	source := []byte("There once was a foo in the world.")
 	result := All(source, mapping)
@@ -166,8 +229,7 @@ func TestReplaceIgnoreCapitalizationInMapping(t *testing.T) {
 
 func TestReplaceMaintainCapitalization(t *testing.T) {
 	t.Run("single word mapping", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo"] = "bar"
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 		source := []byte("There once was a foo in the world. Foo did things.")
 		result := All(source, mapping)
@@ -178,8 +240,7 @@ func TestReplaceMaintainCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("two word mapping", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello world"] = "hey planet"
+		mapping := []common.Mapping{{From: "hello world", To: "hey planet"}}
 
 		source := []byte("Hello World!")
 		result := All(source, mapping)
@@ -190,8 +251,7 @@ func TestReplaceMaintainCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("two word to hyphenated word mapping", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["so called"] = "so-called"
+		mapping := []common.Mapping{{From: "so called", To: "so-called"}}
 
 		source := []byte("A So called 'hypnotoad'")
 		result := All(source, mapping)
@@ -212,8 +272,7 @@ func TestReplaceMaintainCapitalization(t *testing.T) {
 }
 
 func TestReplaceWordAllCaps(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["foo"] = "bar"
+	mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 	source := []byte("This is the FOO.")
 	result := All(source, mapping)
@@ -224,10 +283,135 @@ func TestReplaceWordAllCaps(t *testing.T) {
 	}
 }
 
+func TestReplaceCaseSensitive(t *testing.T) {
+	t.Run("single word mapping", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "JS", To: "JavaScript", CaseSensitive: true}}
+
+		source := []byte("Write JS, not js or Js.")
+		result := All(source, mapping)
+
+		expected := []byte("Write JavaScript, not js or Js.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("two word mapping", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "Go lang", To: "Go language", CaseSensitive: true}}
+
+		source := []byte("Go\nlang or go lang")
+		result := All(source, mapping)
+
+		expected := []byte("Go\nlanguage or go lang")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("with suffix", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "iOS-", To: "iPadOS-", CaseSensitive: true}}
+
+		source := []byte("iOS14 and IOS14")
+		result := All(source, mapping)
+
+		expected := []byte("iPadOS14 and IOS14")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+}
+
+func TestReplaceUnicode(t *testing.T) {
+	t.Run("Latin with diacritics", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "café", To: "bar"}}
+
+		source := []byte("A café, a Café and a CAFÉ.")
+		result := All(source, mapping)
+
+		expected := []byte("A bar, a Bar and a BAR.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("German", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "größe", To: "ausmaß"}}
+
+		source := []byte("Die Größe und die größe.")
+		result := All(source, mapping)
+
+		expected := []byte("Die Ausmaß und die ausmaß.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("Cyrillic", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "добрый день", To: "привет"}}
+
+		source := []byte("Добрый день! ДОБРЫЙ ДЕНЬ!")
+		result := All(source, mapping)
+
+		expected := []byte("Привет! ПРИВЕТ!")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("Greek", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "γεια", To: "χαίρετε"}}
+
+		source := []byte("Γεια σου, γεια.")
+		result := All(source, mapping)
+
+		expected := []byte("Χαίρετε σου, χαίρετε.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("word boundaries", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "caf", To: "bar"}}
+
+		source := []byte("A café, a cafe\u0301 and a caf.")
+		result := All(source, mapping)
+
+		expected := []byte("A café, a cafe\u0301 and a bar.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+
+		mapping = []common.Mapping{{From: "мир", To: "world"}}
+
+		source = []byte("Мир, мирный.")
+		result = All(source, mapping)
+
+		expected = []byte("World, мирный.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("non-word ASCII characters", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
+
+		source := []byte("foo_ [foo] ^foo` foo2")
+		result := All(source, mapping)
+
+		expected := []byte("bar_ [bar] ^bar` foo2")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("affixes", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "-größe", To: "-maß"}}
+
+		source := []byte("Die Schuhgröße.")
+		result := All(source, mapping)
+
+		expected := []byte("Die Schuhmaß.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+}
+
 func TestReplaceToChangeCapitalization(t *testing.T) {
 	t.Run("To titlecase", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo"] = "Foo"
+		mapping := []common.Mapping{{From: "foo", To: "Foo"}}
 
 		s := []byte(`foo FOO Foo fOO FOo`)
 		actual := All(s, mapping)
@@ -238,8 +422,7 @@ func TestReplaceToChangeCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("To lowercase", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["bar"] = "bar"
+		mapping := []common.Mapping{{From: "bar", To: "bar"}}
 
 		s := []byte(`bar BAR Bar bAR BAr`)
 		actual := All(s, mapping)
@@ -250,8 +433,7 @@ func TestReplaceToChangeCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("To all-caps", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["r2-d2"] = "R2-D2"
+		mapping := []common.Mapping{{From: "r2-d2", To: "R2-D2"}}
 
 		s := []byte(`r2-d2 R2-d2 r2-D2`)
 		actual := All(s, mapping)
@@ -262,8 +444,7 @@ func TestReplaceToChangeCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("Multiple words", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello world"] = "Hello World"
+		mapping := []common.Mapping{{From: "hello world", To: "Hello World"}}
 
 		s := []byte(`hello world HELLO WORLD hElLo WoRlD HeLlO wOrLd`)
 		actual := All(s, mapping)
@@ -274,8 +455,7 @@ func TestReplaceToChangeCapitalization(t *testing.T) {
 		}
 	})
 	t.Run("With newline", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello world"] = "Hello World"
+		mapping := []common.Mapping{{From: "hello world", To: "Hello World"}}
 
 		s := []byte(`
 			hello world
@@ -297,8 +477,7 @@ func TestReplaceToChangeCapitalization(t *testing.T) {
 
 func TestReplaceWordWithPrefixes(t *testing.T) {
 	t.Run("maintain prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-ize"] = "-ise"
+		mapping := []common.Mapping{{From: "-ize", To: "-ise"}}
 
 		source := []byte("They Realize that they should not idealize.")
 		result := All(source, mapping)
@@ -309,8 +488,7 @@ func TestReplaceWordWithPrefixes(t *testing.T) {
 		}
 	})
 	t.Run("replace only if preceded by another word", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["- dogs"] = "- cats"
+		mapping := []common.Mapping{{From: "- dogs", To: "- cats"}}
 
 		source := []byte("Dogs are nice and dogs are cool.")
 		result := All(source, mapping)
@@ -321,8 +499,7 @@ func TestReplaceWordWithPrefixes(t *testing.T) {
 		}
 	})
 	t.Run("omit prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-phone"] = "phone"
+		mapping := []common.Mapping{{From: "-phone", To: "phone"}}
 
 		source := []byte("That cat has a telephone.")
 		result := All(source, mapping)
@@ -333,8 +510,7 @@ func TestReplaceWordWithPrefixes(t *testing.T) {
 		}
 	})
 	t.Run("omit the preceding word", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["- people"] = "people"
+		mapping := []common.Mapping{{From: "- people", To: "people"}}
 
 		source := []byte("Cool people are nice and nice people are cool.")
 		result := All(source, mapping)
@@ -348,8 +524,7 @@ func TestReplaceWordWithPrefixes(t *testing.T) {
 
 func TestReplaceWordWithSuffixes(t *testing.T) {
 	t.Run("maintain suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["color-"] = "colour-"
+		mapping := []common.Mapping{{From: "color-", To: "colour-"}}
 
 		source := []byte("The colors on this colorful painting are amazing.")
 		result := All(source, mapping)
@@ -360,8 +535,7 @@ func TestReplaceWordWithSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("replace only if succeeded by another word", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["dog -"] = "cat -"
+		mapping := []common.Mapping{{From: "dog -", To: "cat -"}}
 
 		source := []byte("I have a dog and you have a dog.")
 		result := All(source, mapping)
@@ -372,8 +546,7 @@ func TestReplaceWordWithSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("maintain the succeeding word", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["very -"] = "super -"
+		mapping := []common.Mapping{{From: "very -", To: "super -"}}
 
 		source := []byte("This is a very special day.")
 		result := All(source, mapping)
@@ -384,8 +557,7 @@ func TestReplaceWordWithSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("omit suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["dog-"] = "dog"
+		mapping := []common.Mapping{{From: "dog-", To: "dog"}}
 
 		source := []byte("I have a dog, but you have a small doggy.")
 		result := All(source, mapping)
@@ -396,8 +568,7 @@ func TestReplaceWordWithSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("omit the succeeding word", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["a -"] = "a"
+		mapping := []common.Mapping{{From: "a -", To: "a"}}
 
 		source := []byte("I have a particularly cool dog.")
 		result := All(source, mapping)
@@ -411,8 +582,7 @@ func TestReplaceWordWithSuffixes(t *testing.T) {
 
 func TestReplaceWordWithPrefixesAndSuffixes(t *testing.T) {
 	t.Run("maintain both", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-bloody-"] = "-freaking-"
+		mapping := []common.Mapping{{From: "-bloody-", To: "-freaking-"}}
 
 		source := []byte("It is a fanbloodytastic movie.")
 		result := All(source, mapping)
@@ -423,8 +593,7 @@ func TestReplaceWordWithPrefixesAndSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("omit prefix, maintain suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-b-"] = "b-"
+		mapping := []common.Mapping{{From: "-b-", To: "b-"}}
 
 		source := []byte("abc")
 		result := All(source, mapping)
@@ -435,8 +604,7 @@ func TestReplaceWordWithPrefixesAndSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("maintain prefix, omit suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-b-"] = "-b"
+		mapping := []common.Mapping{{From: "-b-", To: "-b"}}
 
 		source := []byte("abc")
 		result := All(source, mapping)
@@ -447,8 +615,7 @@ func TestReplaceWordWithPrefixesAndSuffixes(t *testing.T) {
 		}
 	})
 	t.Run("omit both", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["-b-"] = "b"
+		mapping := []common.Mapping{{From: "-b-", To: "b"}}
 
 		source := []byte("abc")
 		result := All(source, mapping)
@@ -461,8 +628,7 @@ func TestReplaceWordWithPrefixesAndSuffixes(t *testing.T) {
 }
 
 func TestReplaceWordWithoutPrefixes(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["mail"] = "email"
+	mapping := []common.Mapping{{From: "mail", To: "email"}}
 
 	source := []byte("I send them a mail. And later another email.")
 	result := All(source, mapping)
@@ -474,8 +640,7 @@ func TestReplaceWordWithoutPrefixes(t *testing.T) {
 }
 
 func TestReplaceWordWithoutSuffixes(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["commen"] = "common"
+	mapping := []common.Mapping{{From: "commen", To: "common"}}
 
 	source := []byte("He game a comment that that is quite commen")
 	result := All(source, mapping)
@@ -487,8 +652,7 @@ func TestReplaceWordWithoutSuffixes(t *testing.T) {
 }
 
 func TestReplaceByShorterString(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["fooo"] = "foo"
+	mapping := []common.Mapping{{From: "fooo", To: "foo"}}
 
 	t.Run("one instance of word", func(t *testing.T) {
 		source := []byte("This is a fooo.")
@@ -511,8 +675,7 @@ func TestReplaceByShorterString(t *testing.T) {
 }
 
 func TestReplaceByLongerString(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping["fo"] = "foo"
+	mapping := []common.Mapping{{From: "fo", To: "foo"}}
 
 	t.Run("one instance of word", func(t *testing.T) {
 		source := []byte("This is a fo.")
@@ -536,8 +699,7 @@ func TestReplaceByLongerString(t *testing.T) {
 
 func TestReplacePhraseNewlineInSource(t *testing.T) {
 	t.Run("newline without indentation", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello world"] = "hey planet"
+		mapping := []common.Mapping{{From: "hello world", To: "hey planet"}}
 
 		source := []byte("lorem ipsum hello\nworld dolor sit amet.")
 		result := All(source, mapping)
@@ -548,8 +710,7 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 		}
 	})
 	t.Run("newline with indentation", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello world"] = "hey planet"
+		mapping := []common.Mapping{{From: "hello world", To: "hey planet"}}
 
 		source := []byte("lorem ipsum hello\n  world dolor sit amet.")
 		result := All(source, mapping)
@@ -560,8 +721,7 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 		}
 	})
 	t.Run("space in from but not in to", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo bar"] = "foobar"
+		mapping := []common.Mapping{{From: "foo bar", To: "foobar"}}
 
 		source := []byte("lorem ipsum foo\nbar dolor sit amet.")
 		result := All(source, mapping)
@@ -572,8 +732,7 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 		}
 	})
 	t.Run("space in from but not in to, with indentation", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo bar"] = "foobar"
+		mapping := []common.Mapping{{From: "foo bar", To: "foobar"}}
 
 		source := []byte("lorem ipsum foo\n  bar dolor sit amet.")
 		result := All(source, mapping)
@@ -584,8 +743,7 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 		}
 	})
 	t.Run("less spaces in from than in to", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["a dog"] = "an amazing dog"
+		mapping := []common.Mapping{{From: "a dog", To: "an amazing dog"}}
 
 		source := []byte("lorem ipsum a\ndog dolor sit amet.")
 		result := All(source, mapping)
@@ -596,8 +754,7 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 		}
 	})
 	t.Run("more spaces in from than in to", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["hello beautiful world"] = "hey planet"
+		mapping := []common.Mapping{{From: "hello beautiful world", To: "hey planet"}}
 
 		source := []byte("lorem ipsum hello\nbeautiful world dolor sit amet.")
 		result := All(source, mapping)
@@ -615,12 +772,36 @@ func TestReplacePhraseNewlineInSource(t *testing.T) {
 			reportIncorrectReplacement(t, expected, result)
 		}
 	})
+	t.Run("space in from but not in to, at the end", func(t *testing.T) {
+		source := []byte("hello\nworld")
+		expected := []byte("bye\n")
+
+		for _, caseSensitive := range []bool{false, true} {
+			mapping := []common.Mapping{
+				{From: "hello world", To: "bye", CaseSensitive: caseSensitive},
+			}
+
+			r := New(mapping)
+			result, changes := r.AllChanges(source)
+			if !bytes.Equal(result, expected) {
+				reportIncorrectReplacement(t, expected, result)
+			}
+
+			if len(changes) != 1 || changes[0].End != len(source) {
+				t.Errorf("Unexpected changes (got %+v)", changes)
+			}
+
+			result, _ = r.AllSimultaneousChanges(source)
+			if !bytes.Equal(result, expected) {
+				reportIncorrectReplacement(t, expected, result)
+			}
+		}
+	})
 }
 
 func TestReplaceEscapeHyphen(t *testing.T) {
 	t.Run("prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`\-foobar`] = `foobar`
+		mapping := []common.Mapping{{From: `\-foobar`, To: `foobar`}}
 
 		source := []byte(`-foobar`)
 		result := All(source, mapping)
@@ -631,8 +812,7 @@ func TestReplaceEscapeHyphen(t *testing.T) {
 		}
 	})
 	t.Run("suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`world\-`] = `world!`
+		mapping := []common.Mapping{{From: `world\-`, To: `world!`}}
 
 		source := []byte(`Hello world-`)
 		result := All(source, mapping)
@@ -646,8 +826,7 @@ func TestReplaceEscapeHyphen(t *testing.T) {
 
 func TestReplaceEscapeEscapeCharacter(t *testing.T) {
 	t.Run("prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`\\bar`] = `bar`
+		mapping := []common.Mapping{{From: `\\bar`, To: `bar`}}
 
 		source := []byte(`foo \bar`)
 		result := All(source, mapping)
@@ -658,8 +837,7 @@ func TestReplaceEscapeEscapeCharacter(t *testing.T) {
 		}
 	})
 	t.Run("suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`foo\\`] = `foo`
+		mapping := []common.Mapping{{From: `foo\\`, To: `foo`}}
 
 		source := []byte(`foo\ bar`)
 		result := All(source, mapping)
@@ -672,8 +850,7 @@ func TestReplaceEscapeEscapeCharacter(t *testing.T) {
 }
 
 func TestReplaceEmptyFromValue(t *testing.T) {
-	mapping := make(map[string]string)
-	mapping[""] = "foobar"
+	mapping := []common.Mapping{{From: "", To: "foobar"}}
 
 	s := []byte("Hello world!")
 	result := All(s, mapping)
@@ -683,10 +860,30 @@ func TestReplaceEmptyFromValue(t *testing.T) {
 	}
 }
 
+func TestValidateMapping(t *testing.T) {
+	valid := []common.Mapping{{From: "cat", To: "dog"}, {From: "-cat", To: "-dog"}}
+	for _, m := range valid {
+		if err := ValidateMapping(m); err != nil {
+			t.Errorf("Unexpected error for '%s,%s' (got '%s')", m.From, m.To, err)
+		}
+	}
+
+	invalid := []common.Mapping{
+		{From: "", To: "dog"},
+		{From: "cat", To: " "},
+		{From: "-", To: "dog"},
+		{From: "\xff", To: "dog"},
+	}
+	for _, m := range invalid {
+		if err := ValidateMapping(m); err == nil {
+			t.Errorf("Expected an error for '%s,%s'", m.From, m.To)
+		}
+	}
+}
+
 func TestKeepNonExistentAffix(t *testing.T) {
 	t.Run("keep non-existent prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`r`] = `-x`
+		mapping := []common.Mapping{{From: `r`, To: `-x`}}
 
 		source := []byte(`foo r bar`)
 		result := All(source, mapping)
@@ -697,8 +894,7 @@ func TestKeepNonExistentAffix(t *testing.T) {
 		}
 	})
 	t.Run("keep non-existent suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`b`] = `x-`
+		mapping := []common.Mapping{{From: `b`, To: `x-`}}
 
 		source := []byte(`foo b bar`)
 		result := All(source, mapping)
@@ -709,8 +905,7 @@ func TestKeepNonExistentAffix(t *testing.T) {
 		}
 	})
 	t.Run("keep non-existent prefix and suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`a`] = `-x-`
+		mapping := []common.Mapping{{From: `a`, To: `-x-`}}
 
 		source := []byte(`foo a bar`)
 		result := All(source, mapping)
@@ -724,8 +919,7 @@ func TestKeepNonExistentAffix(t *testing.T) {
 
 func TestReplaceCornerCases(t *testing.T) {
 	t.Run("empty search string", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo"] = "bar"
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
 
 		source := []byte{}
 		result := All(source, mapping)
@@ -735,8 +929,7 @@ func TestReplaceCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("empty from string", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[""] = "bar"
+		mapping := []common.Mapping{{From: "", To: "bar"}}
 
 		source := []byte("foobar")
 		result := All(source, mapping)
@@ -746,8 +939,7 @@ func TestReplaceCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("empty to string", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["foo"] = ""
+		mapping := []common.Mapping{{From: "foo", To: ""}}
 
 		source := []byte("foo bar foo")
 		result := All(source, mapping)
@@ -757,8 +949,7 @@ func TestReplaceCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("search string contains UTF-8 character", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["\xbf"] = "pikachu"
+		mapping := []common.Mapping{{From: "\xbf", To: "pikachu"}}
 
 		source := []byte("foobar")
 		result := All(source, mapping)
@@ -771,8 +962,7 @@ func TestReplaceCornerCases(t *testing.T) {
 
 func TestReplaceAffixInFromCornerCases(t *testing.T) {
 	t.Run("only a hyphen", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`-`] = `x`
+		mapping := []common.Mapping{{From: `-`, To: `x`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -782,8 +972,7 @@ func TestReplaceAffixInFromCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("only two hyphens", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`--`] = `x`
+		mapping := []common.Mapping{{From: `--`, To: `x`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -793,8 +982,7 @@ func TestReplaceAffixInFromCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("two hyphens with a space", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`- -`] = `-`
+		mapping := []common.Mapping{{From: `- -`, To: `-`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -807,8 +995,7 @@ func TestReplaceAffixInFromCornerCases(t *testing.T) {
 
 func TestReplaceAffixInToCornerCases(t *testing.T) {
 	t.Run("only a hyphen", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`world`] = `-`
+		mapping := []common.Mapping{{From: `world`, To: `-`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -818,8 +1005,7 @@ func TestReplaceAffixInToCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("only two hyphens", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`hello`] = `--`
+		mapping := []common.Mapping{{From: `hello`, To: `--`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -829,8 +1015,7 @@ func TestReplaceAffixInToCornerCases(t *testing.T) {
 		}
 	})
 	t.Run("two hyphens with a space", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping[`hello`] = `- -`
+		mapping := []common.Mapping{{From: `hello`, To: `- -`}}
 
 		source := []byte(`Hello world!`)
 		result := All(source, mapping)
@@ -843,8 +1028,7 @@ func TestReplaceAffixInToCornerCases(t *testing.T) {
 
 func TestDoublePrefixSuffixMatch(t *testing.T) {
 	t.Run("double prefix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["b -"] = "bulbasaur"
+		mapping := []common.Mapping{{From: "b -", To: "bulbasaur"}}
 
 		source := []byte("b b\nfoobar")
 		result := All(source, mapping)
@@ -855,8 +1039,7 @@ func TestDoublePrefixSuffixMatch(t *testing.T) {
 		}
 	})
 	t.Run("double suffix", func(t *testing.T) {
-		mapping := make(map[string]string)
-		mapping["- b"] = "bulbasaur"
+		mapping := []common.Mapping{{From: "- b", To: "bulbasaur"}}
 
 		source := []byte("a\nb b")
 		result := All(source, mapping)
@@ -867,3 +1050,267 @@ func TestDoublePrefixSuffixMatch(t *testing.T) {
 		}
 	})
 }
+
+func TestReplaceSimultaneous(t *testing.T) {
+	t.Run("swap", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "cat", To: "dog"},
+		}
+
+		source := []byte("I have a dog and you have a Cat.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("I have a cat and you have a Dog.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("no cascading", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "cat", To: "bird"},
+		}
+
+		source := []byte("I have a dog.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("I have a cat.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("leftmost match", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog house", To: "kennel"},
+			{From: "big dog", To: "large dog"},
+		}
+
+		source := []byte("A big dog house.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("A large dog house.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("longest match", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "dog house", To: "kennel"},
+		}
+
+		source := []byte("A dog in a dog house.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("A cat in a kennel.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("equally long matches", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: "cat"},
+			{From: "dog", To: "bird"},
+			{From: "Dog", To: "horse"},
+		}
+
+		source := []byte("A dog.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("A cat.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+	t.Run("invalid mapping", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "dog", To: ""},
+			{From: "cat", To: "dog"},
+		}
+
+		source := []byte("A dog and a cat.")
+		result := AllSimultaneous(source, mapping)
+
+		expected := []byte("A dog and a dog.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+}
+
+func TestSelectNonOverlapping(t *testing.T) {
+	t.Run("no replacements", func(t *testing.T) {
+		selected := selectNonOverlapping(nil)
+		if len(selected) != 0 {
+			t.Errorf("Unexpected number of replacements (got %d)", len(selected))
+		}
+	})
+	t.Run("disjoint replacements", func(t *testing.T) {
+		rs := []replacement{
+			{start: 5, end: 7, value: "b"},
+			{start: 0, end: 3, value: "a"},
+		}
+
+		selected := selectNonOverlapping(rs)
+		if len(selected) != 2 {
+			t.Fatalf("Unexpected number of replacements (got %d)", len(selected))
+		}
+
+		if selected[0].value != "a" || selected[1].value != "b" {
+			t.Errorf("Unexpected order of replacements (got %v)", selected)
+		}
+	})
+	t.Run("overlapping replacements", func(t *testing.T) {
+		rs := []replacement{
+			{start: 2, end: 9, value: "c"},
+			{start: 0, end: 3, value: "a"},
+			{start: 0, end: 4, value: "b"},
+			{start: 4, end: 5, value: "d"},
+		}
+
+		selected := selectNonOverlapping(rs)
+		if len(selected) != 2 {
+			t.Fatalf("Unexpected number of replacements (got %d)", len(selected))
+		}
+
+		if selected[0].value != "b" || selected[1].value != "d" {
+			t.Errorf("Unexpected replacements (got %v)", selected)
+		}
+	})
+}
+
+func TestReplacer(t *testing.T) {
+	t.Run("omits invalid mappings", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "", To: "foo"},
+			{From: "\xbf", To: "bar"},
+			{From: "hello", To: "hey"},
+		}
+
+		r := New(mapping)
+		if len(r.rules) != 1 {
+			t.Fatalf("Unexpected number of rules (got %d)", len(r.rules))
+		}
+
+		if r.rules[0].mapping != mapping[2] {
+			t.Errorf("Unexpected rule (got '%s' to '%s')", r.rules[0].mapping.From, r.rules[0].mapping.To)
+		}
+	})
+	t.Run("reusable", func(t *testing.T) {
+		mapping := []common.Mapping{{From: "foo", To: "bar"}}
+		r := New(mapping)
+
+		for _, source := range []string{"foo", "A foo.", "FOO and foo"} {
+			result := r.All([]byte(source))
+
+			expected := All([]byte(source), mapping)
+			if !bytes.Equal(result, expected) {
+				reportIncorrectReplacement(t, expected, result)
+			}
+		}
+	})
+	t.Run("cascading into a new match", func(t *testing.T) {
+		mapping := []common.Mapping{
+			{From: "cat", To: "dog"},
+			{From: "big dog", To: "large dog"},
+		}
+
+		source := []byte("A big cat.")
+		result := New(mapping).All(source)
+
+		expected := []byte("A large dog.")
+		if !bytes.Equal(result, expected) {
+			reportIncorrectReplacement(t, expected, result)
+		}
+	})
+}
+
+func TestReplacerMatchesNaive(t *testing.T) {
+	mapping, s := benchmarkInput(1024)
+
+	expected := naiveAll(s, mapping)
+	result := New(mapping).All(s)
+	if !bytes.Equal(result, expected) {
+		t.Error("Result of the Replacer differs from the naive replacement")
+	}
+}
+
+// The number of mappings used in benchmarks.
+const benchmarkMappingSize = 3000
+
+// Get a list of mappings and a text of approximately `size` bytes to benchmark
+// replacing with. About one in every ten words of the text is replaced.
+func benchmarkInput(size int) ([]common.Mapping, []byte) {
+	mapping := make([]common.Mapping, benchmarkMappingSize)
+	for i := range mapping {
+		mapping[i] = common.Mapping{
+			From: fmt.Sprintf("term%d", i),
+			To:   fmt.Sprintf("word%d", i),
+		}
+	}
+
+	var bb bytes.Buffer
+	for i := 0; bb.Len() < size; i++ {
+		if i%10 == 0 {
+			fmt.Fprintf(&bb, "term%d ", (i*7)%benchmarkMappingSize)
+		} else {
+			bb.WriteString("lorem ipsum ")
+		}
+	}
+
+	return mapping, bb.Bytes()
+}
+
+// Replace substrings of `s` by compiling and applying every mapping one by one.
+// This is how replacements were made before mappings were compiled into a
+// Replacer.
+func naiveAll(s []byte, m []common.Mapping) []byte {
+	for i := range m {
+		if ValidateMapping(m[i]) == nil {
+			query := compileQuery(m[i].From, m[i].CaseSensitive)
+			r := &rule{mapping: m[i], query: query}
+			s = applyReplacements(s, r.replacements(s))
+		}
+	}
+
+	return s
+}
+
+func BenchmarkReplaceNaive(b *testing.B) {
+	mapping, s := benchmarkInput(16 * 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		naiveAll(s, mapping)
+	}
+}
+
+func BenchmarkReplacerAll(b *testing.B) {
+	mapping, s := benchmarkInput(16 * 1024)
+	r := New(mapping)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.All(s)
+	}
+}
+
+func BenchmarkReplacerAllSimultaneous(b *testing.B) {
+	mapping, s := benchmarkInput(16 * 1024)
+	r := New(mapping)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.AllSimultaneous(s)
+	}
+}
+
+func BenchmarkReplacerNew(b *testing.B) {
+	mapping, _ := benchmarkInput(0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(mapping)
+	}
+}
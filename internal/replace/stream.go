@@ -0,0 +1,200 @@
+package replace
+
+import (
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The number of bytes read from the input of a stream before (a part of) it is
+// replaced, in addition to the window of the Replacer.
+const streamChunkSize = 64 * 1024
+
+// The number of bytes by which the window of a Replacer exceeds the longest
+// possible match of its rules without prefix, suffix, or additional whitespace.
+// This leaves room for prefixes and suffixes as well as for the whitespace in
+// phrases spanning more than a single whitespace character.
+const streamMargin = 1024
+
+// Get the window for the `rules`, i.e. the maximum length in bytes of a match
+// of any of the `rules` that is guaranteed to be found when streaming.
+func getWindow(rules []*rule) int {
+	longest := 0
+	for _, r := range rules {
+		literal := toLiteralString(r.mapping.From)
+		longest = maxInt(longest, utf8.UTFMax*utf8.RuneCountInString(literal))
+	}
+
+	return longest + streamMargin
+}
+
+// Check whether the rune `r` is a word character, see wordCharClass.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r)
+}
+
+// Check whether `s` can be split at the index `i` without splitting a character
+// or a word.
+func isSplitPoint(s []byte, i int) bool {
+	if i <= 0 || i >= len(s) || !utf8.RuneStart(s[i]) {
+		return false
+	}
+
+	before, _ := utf8.DecodeLastRune(s[:i])
+	after, _ := utf8.DecodeRune(s[i:])
+	return !isWordRune(before) || !isWordRune(after)
+}
+
+// Find the index in the original string `s` at which the string tracked by `t`
+// is split when streaming, given that no index beyond `limit` may be used and
+// no index beyond `updatedLimit` in the updated string. It returns the index in
+// `s` as well as the corresponding index in the updated string. The index is
+// never inside a replacement nor inside a word. If there is no such index, both
+// return values are 0.
+func findSplit(
+	s []byte,
+	t *tracker,
+	limit, updatedLimit int,
+) (original, updated int) {
+	positions := make([]int, len(t.pieces)+1)
+	for i, p := range t.pieces {
+		positions[i+1] = positions[i] + p.length
+	}
+
+	for i := len(t.pieces) - 1; i >= 0; i-- {
+		p := t.pieces[i]
+		if p.start > limit {
+			continue
+		}
+
+		if p.generated {
+			if p.end <= limit && positions[i+1] <= updatedLimit &&
+				isSplitPoint(s, p.end) {
+				return p.end, positions[i+1]
+			}
+
+			continue
+		}
+
+		last := minInt(p.end, minInt(limit, p.start+updatedLimit-positions[i]))
+		for j := last; j >= p.start && j > 0; j-- {
+			if isSplitPoint(s, j) {
+				return j, positions[i] + (j - p.start)
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+// Read from `src` into `buf` until it holds at least `size` bytes or the end of
+// `src` is reached. It returns the updated `buf` and whether the end of `src`
+// was reached.
+func fill(src io.Reader, buf []byte, size int) ([]byte, bool, error) {
+	for len(buf) < size {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+
+		n, err := src.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, true, nil
+		} else if err != nil {
+			return buf, false, err
+		}
+	}
+
+	return buf, false, nil
+}
+
+// Replace substrings of the content of `src` using the `apply` function and
+// write the result to `dst`, reading `chunkSize` bytes at a time. It returns
+// the Changes made, with positions in terms of the entire content of `src`.
+//
+// The content is processed in parts. Every part consists of the data read so
+// far, of which everything but the last window is written to `dst`. The last
+// window is processed again as part of the next part. If the part cannot be
+// split, see findSplit, another chunk is read into it first.
+func (r *Replacer) stream(
+	dst io.Writer,
+	src io.Reader,
+	apply func(s []byte, t *tracker) []byte,
+	chunkSize int,
+) (changes []Change, err error) {
+	var buf []byte
+
+	offset, size := 0, chunkSize+r.window
+	for eof := false; !eof; {
+		buf, eof, err = fill(src, buf, size)
+		if err != nil {
+			return changes, err
+		}
+
+		t := newTracker(buf)
+		out := apply(buf, t)
+
+		split, updatedSplit := len(buf), len(out)
+		if !eof {
+			split, updatedSplit = findSplit(buf, t, len(buf)-r.window, len(out))
+		}
+
+		if split == 0 && !eof {
+			size += chunkSize
+			continue
+		}
+
+		if _, err = dst.Write(out[:updatedSplit]); err != nil {
+			return changes, err
+		}
+
+		for _, c := range t.getChanges() {
+			if c.End <= split {
+				c.Start, c.End = c.Start+offset, c.End+offset
+				changes = append(changes, c)
+			}
+		}
+
+		buf = append(buf[:0:0], buf[split:]...)
+		offset, size = offset+split, chunkSize+r.window
+	}
+
+	return changes, nil
+}
+
+// Stream replaces substrings of the content read from `src` according to the
+// rules of the Replacer, like AllChanges, and writes the result to `dst`. It
+// returns the Changes made, with positions in terms of the content of `src`.
+// Unlike AllChanges, the content of `src` is never held in memory all at once,
+// making Stream suitable for content of any size.
+//
+// The content is processed in overlapping parts, the last window of each part
+// being processed again as the start of the next. The window is sized to the
+// longest possible match of any rule with a margin for prefixes, suffixes, and
+// additional whitespace in phrases. Parts are only ever split outside of
+// replacements and outside of words, a part without such a point is extended
+// until it has one or the end of `src` is reached. Hence, the result equals
+// that of AllChanges for any match up to the size of the window, including
+// phrases that span the boundary between two parts. A match longer than the
+// window, for example due to a very long run of whitespace in a phrase, may not
+// be replaced if it spans such a boundary.
+func (r *Replacer) Stream(dst io.Writer, src io.Reader) ([]Change, error) {
+	apply := func(s []byte, t *tracker) []byte {
+		return r.all(s, t, nil)
+	}
+
+	return r.stream(dst, src, apply, streamChunkSize)
+}
+
+// StreamSimultaneous is like Stream but replaces substrings like
+// AllSimultaneousChanges.
+func (r *Replacer) StreamSimultaneous(
+	dst io.Writer,
+	src io.Reader,
+) ([]Change, error) {
+	apply := func(s []byte, t *tracker) []byte {
+		return r.allSimultaneous(s, t, nil)
+	}
+
+	return r.stream(dst, src, apply, streamChunkSize)
+}
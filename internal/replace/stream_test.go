@@ -0,0 +1,156 @@
+package replace
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
+
+func TestGetWindow(t *testing.T) {
+	r := New([]common.Mapping{
+		{From: "cat", To: "dog"},
+		{From: "hello world", To: "hey"},
+		{From: "-ß-", To: "ss"},
+	})
+
+	expected := 4*len("hello world") + streamMargin
+	if r.window != expected {
+		t.Errorf("Unexpected window (got %d)", r.window)
+	}
+}
+
+func TestIsSplitPoint(t *testing.T) {
+	s := []byte("foo bar.ß")
+	cases := map[int]bool{
+		0:  false,
+		1:  false,
+		3:  true,
+		4:  true,
+		7:  true,
+		8:  true,
+		9:  false,
+		10: false,
+	}
+
+	for i, expected := range cases {
+		if actual := isSplitPoint(s, i); actual != expected {
+			t.Errorf("Unexpected result for %d (got %t)", i, actual)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	mapping := []common.Mapping{
+		{From: "hello world", To: "hey planet"},
+		{From: "cat", To: "dog"},
+		{From: "dog-", To: "horse-"},
+		{From: "-ß", To: "ss"},
+	}
+	r := New(mapping)
+
+	paragraph := "Hello\nworld, the cat and dogs met a Catß in the hello   world.\n"
+	s := []byte(stringsx.Repeat(paragraph, 200) + "hello world")
+
+	t.Run("Sequential", func(t *testing.T) {
+		expected, expectedChanges := r.AllChanges(s)
+		for _, chunkSize := range []int{100, 1000, len(s)} {
+			var bb bytes.Buffer
+			changes, err := r.stream(&bb, bytes.NewReader(s), func(s []byte, t *tracker) []byte {
+				return r.all(s, t, nil)
+			}, chunkSize)
+			if err != nil {
+				t.Fatalf("Unexpected error (%s)", err)
+			}
+
+			if !bytes.Equal(bb.Bytes(), expected) {
+				t.Errorf("Unexpected output for chunk size %d", chunkSize)
+			}
+
+			if !reflect.DeepEqual(changes, expectedChanges) {
+				t.Errorf("Unexpected changes for chunk size %d", chunkSize)
+			}
+		}
+	})
+	t.Run("Simultaneous", func(t *testing.T) {
+		expected, expectedChanges := r.AllSimultaneousChanges(s)
+
+		var bb bytes.Buffer
+		changes, err := r.StreamSimultaneous(&bb, iotest.OneByteReader(bytes.NewReader(s)))
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if !bytes.Equal(bb.Bytes(), expected) {
+			reportIncorrectReplacement(t, expected, bb.Bytes())
+		}
+
+		if !reflect.DeepEqual(changes, expectedChanges) {
+			t.Error("Unexpected changes")
+		}
+	})
+	t.Run("Long words", func(t *testing.T) {
+		word := stringsx.Repeat("ä", 10*r.window)
+		s := []byte(word + " cat " + word)
+
+		var bb bytes.Buffer
+		if _, err := r.stream(&bb, bytes.NewReader(s), func(s []byte, t *tracker) []byte {
+			return r.all(s, t, nil)
+		}, 100); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if expected := r.All(s); !bytes.Equal(bb.Bytes(), expected) {
+			t.Error("Unexpected output for long words")
+		}
+	})
+	t.Run("No word boundary", func(t *testing.T) {
+		r := New([]common.Mapping{{From: "dog", To: "cat"}})
+		word := stringsx.Repeat("a", streamChunkSize)
+		s := []byte(word + "dog " + word)
+
+		var bb bytes.Buffer
+		changes, err := r.Stream(&bb, iotest.OneByteReader(bytes.NewReader(s)))
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if expected := r.All(s); !bytes.Equal(bb.Bytes(), expected) {
+			t.Error("Unexpected output for input without a word boundary")
+		}
+
+		if len(changes) != 0 {
+			t.Errorf("Unexpected changes (got %+v)", changes)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		var bb bytes.Buffer
+		changes, err := r.Stream(&bb, bytes.NewReader(nil))
+		if err != nil || bb.Len() != 0 || len(changes) != 0 {
+			t.Errorf("Unexpected result (got '%s', %v, %v)", bb.Bytes(), changes, err)
+		}
+	})
+	t.Run("Reading error", func(t *testing.T) {
+		var bb bytes.Buffer
+		_, err := r.Stream(&bb, iotest.TimeoutReader(bytes.NewReader(s)))
+		if err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+	t.Run("Writing error", func(t *testing.T) {
+		_, err := r.Stream(errWriter{}, bytes.NewReader(s))
+		if err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
+
+// The errWriter type is an io.Writer that fails every write.
+type errWriter struct{}
+
+func (w errWriter) Write(data []byte) (int, error) {
+	return 0, iotest.ErrTimeout
+}
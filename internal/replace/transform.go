@@ -0,0 +1,80 @@
+package replace
+
+import (
+	"golang.org/x/text/transform"
+)
+
+// Transformer is a transform.Transformer that replaces substrings according to
+// the rules of a Replacer. It can be used with transform.NewReader,
+// transform.NewWriter, and transform.Chain to combine it with other
+// transformations, such as decoding or normalization.
+//
+// Like Stream, a Transformer processes its input in parts. It only consumes the
+// input up to a point outside of a word where no replacement can span the
+// boundary between the part consumed and the remainder, and it reports
+// transform.ErrShortSrc if the input it is given does not extend at least a
+// window beyond such a point. To make progress, the input buffer given to a
+// Transformer must therefore be larger than the window of the Replacer, as is
+// the case for the buffers of transform.Reader and transform.Writer for any
+// rule of up to a few hundred characters. A word that does not fit in the input
+// buffer is never split, so a transform.Reader reports transform.ErrShortSrc
+// for it instead.
+//
+// A Transformer keeps no state between calls, so it is safe for concurrent use.
+type Transformer struct {
+	transform.NopResetter
+
+	// The function to apply the rules of the Replacer to a string.
+	apply func(s []byte, t *tracker) []byte
+
+	// The window of the Replacer.
+	window int
+}
+
+// Transformer gets a Transformer that replaces substrings like AllChanges.
+func (r *Replacer) Transformer() *Transformer {
+	return &Transformer{
+		apply: func(s []byte, t *tracker) []byte {
+			return r.all(s, t, nil)
+		},
+		window: r.window,
+	}
+}
+
+// SimultaneousTransformer gets a Transformer that replaces substrings like
+// AllSimultaneousChanges.
+func (r *Replacer) SimultaneousTransformer() *Transformer {
+	return &Transformer{
+		apply: func(s []byte, t *tracker) []byte {
+			return r.allSimultaneous(s, t, nil)
+		},
+		window: r.window,
+	}
+}
+
+// Transform writes to `dst` the replaced bytes read from `src`, see
+// transform.Transformer. Unless `atEOF` is true, the last window of `src` is
+// never consumed and transform.ErrShortSrc is returned. Nothing is consumed if
+// the rest of `src` has no point outside of a word to split it at. If the replaced part
+// of `src` does not fit in `dst`, as much of it as possible is consumed and
+// transform.ErrShortDst is returned.
+func (tr *Transformer) Transform(
+	dst, src []byte,
+	atEOF bool,
+) (nDst, nSrc int, err error) {
+	t := newTracker(src)
+	out := tr.apply(src, t)
+
+	split, updatedSplit := len(src), len(out)
+	if !atEOF {
+		split, updatedSplit = findSplit(src, t, len(src)-tr.window, len(out))
+		err = transform.ErrShortSrc
+	}
+
+	if updatedSplit > len(dst) {
+		split, updatedSplit = findSplit(src, t, split, len(dst))
+		err = transform.ErrShortDst
+	}
+
+	return copy(dst, out[:updatedSplit]), split, err
+}
@@ -0,0 +1,123 @@
+package replace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"golang.org/x/text/transform"
+)
+
+func TestTransformer(t *testing.T) {
+	mapping := []common.Mapping{
+		{From: "hello world", To: "hey planet"},
+		{From: "cat", To: "dog"},
+		{From: "dog-", To: "horse-"},
+		{From: "-ß", To: "ss"},
+	}
+	r := New(mapping)
+
+	paragraph := "Hello\nworld, the cat and dogs met a Catß in the hello   world.\n"
+	s := []byte(stringsx.Repeat(paragraph, 200) + "hello world")
+
+	t.Run("Reader", func(t *testing.T) {
+		reader := transform.NewReader(iotest.HalfReader(bytes.NewReader(s)), r.Transformer())
+		out, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if expected := r.All(s); !bytes.Equal(out, expected) {
+			reportIncorrectReplacement(t, expected, out)
+		}
+	})
+	t.Run("Writer", func(t *testing.T) {
+		var bb bytes.Buffer
+		writer := transform.NewWriter(&bb, r.SimultaneousTransformer())
+		for i := 0; i < len(s); i += 100 {
+			if _, err := writer.Write(s[i:minInt(i+100, len(s))]); err != nil {
+				t.Fatalf("Unexpected error (%s)", err)
+			}
+		}
+
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if expected := r.AllSimultaneous(s); !bytes.Equal(bb.Bytes(), expected) {
+			reportIncorrectReplacement(t, expected, bb.Bytes())
+		}
+	})
+	t.Run("Phrase split across buffers", func(t *testing.T) {
+		for offset := 2800; offset < 4400; offset += 13 {
+			s := []byte(stringsx.Repeat("a ", offset/2) + "hello\n  world")
+
+			reader := transform.NewReader(bytes.NewReader(s), r.Transformer())
+			out, err := ioutil.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Unexpected error (%s)", err)
+			}
+
+			if !bytes.HasSuffix(out, []byte("hey\n  planet")) {
+				t.Errorf("Phrase not replaced at offset %d", offset)
+			}
+		}
+	})
+	t.Run("No word boundary", func(t *testing.T) {
+		r := New([]common.Mapping{{From: "dog", To: "cat"}})
+		s := []byte(stringsx.Repeat("a", 65536) + "dog ")
+
+		reader := transform.NewReader(iotest.OneByteReader(bytes.NewReader(s)), r.Transformer())
+		out, err := ioutil.ReadAll(reader)
+		if err != transform.ErrShortSrc {
+			t.Errorf("Unexpected error (got %v)", err)
+		}
+
+		if bytes.Contains(out, []byte("cat")) {
+			t.Error("Unexpected replacement inside a word")
+		}
+
+		dst := make([]byte, len(s))
+		src := s[:8192]
+		nDst, nSrc, err := r.Transformer().Transform(dst, src, false)
+		if err != transform.ErrShortSrc || nDst != 0 || nSrc != 0 {
+			t.Errorf("Unexpected result (got %d, %d, %v)", nDst, nSrc, err)
+		}
+	})
+	t.Run("Short source", func(t *testing.T) {
+		dst := make([]byte, 100)
+		src := []byte("Hello world")
+
+		nDst, nSrc, err := r.Transformer().Transform(dst, src, false)
+		if err != transform.ErrShortSrc || nDst != 0 || nSrc != 0 {
+			t.Errorf("Unexpected result (got %d, %d, %v)", nDst, nSrc, err)
+		}
+
+		nDst, nSrc, err = r.Transformer().Transform(dst, src, true)
+		if err != nil || nSrc != len(src) || string(dst[:nDst]) != "Hey planet" {
+			t.Errorf("Unexpected result (got '%s', %d, %v)", dst[:nDst], nSrc, err)
+		}
+	})
+	t.Run("Short destination", func(t *testing.T) {
+		src := []byte("hello world, hello world")
+		dst := make([]byte, 14)
+
+		nDst, nSrc, err := r.Transformer().Transform(dst, src, true)
+		if err != transform.ErrShortDst {
+			t.Fatalf("Unexpected error (got %v)", err)
+		}
+
+		if string(dst[:nDst]) != "hey planet, " || string(src[:nSrc]) != "hello world, " {
+			t.Errorf("Unexpected result (got '%s' for '%s')", dst[:nDst], src[:nSrc])
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		out, n, err := transform.String(r.Transformer(), "")
+		if err != nil || n != 0 || out != "" {
+			t.Errorf("Unexpected result (got '%s', %d, %v)", out, n, err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+/*
+Package wordrow provides the functionality of the wordrow CLI as a library, so
+that it can be embedded in other programs. It offers parsers for the supported
+mapping file formats, a compiled Replacer to replace words in any number of
+inputs, and functions to find and update files.
+
+Rules to replace words are parsed from mapping files, or defined directly.
+
+	rules, err := wordrow.ParseFile("animals.csv", wordrow.FormatCSV)
+	rules = append(rules, wordrow.Rule{From: "cat", To: "dog"})
+
+The rules are compiled into a Replacer once, which can be used for any number of
+inputs, also from multiple goroutines at the same time. Invalid rules are left
+out of the Replacer and reported as errors, the package never logs anything.
+
+	r, errs := wordrow.New(rules, wordrow.Options{})
+	updated, changes := r.Replace([]byte("A cat and a horse"))
+
+A Replacer can also be used as a transform.Transformer, for example to replace
+words in content that is decoded or normalized on the fly.
+
+	reader := transform.NewReader(input, r.Transformer())
+
+A Replacer can also update files, replacing them atomically where the
+permissions of their directory allow it.
+
+	paths, errs := wordrow.ResolveFiles(wordrow.WalkOptions{}, "docs")
+	results := r.ReplaceFiles(ctx, paths, wordrow.FileOptions{})
+
+Every Change made to an input can be located in it as a Match, and the Matches
+in many inputs can be collected in a Report.
+*/
+package wordrow
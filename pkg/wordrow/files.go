@@ -0,0 +1,317 @@
+package wordrow
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+)
+
+// DefaultStreamThreshold is the size in bytes above which files are streamed
+// rather than read into memory at once by default, see FileOptions.
+const DefaultStreamThreshold int64 = 64 * 1024 * 1024
+
+// SymlinkPolicy is the policy for symbolic links found while walking a
+// directory, see WalkOptions.
+type SymlinkPolicy int
+
+// The policies for symbolic links found while walking a directory.
+const (
+	// SymlinksSkip is the policy to leave out symbolic links.
+	SymlinksSkip SymlinkPolicy = iota
+
+	// SymlinksFollow is the policy to follow symbolic links to files and
+	// directories. Links to a directory that is already being walked are left out
+	// to avoid cycles.
+	SymlinksFollow
+
+	// SymlinksError is the policy to leave out symbolic links and return an error
+	// for each of them.
+	SymlinksError
+)
+
+// WalkOptions configures how directories are walked and which files are kept
+// when resolving input files, see ResolveFiles. The zero value walks
+// directories without a depth limit, keeps every file, leaves out symbolic
+// links, does not walk hidden directories (e.g. ".git") or "node_modules"
+// directories, and leaves out files and directories ignored by .gitignore or
+// .wordrowignore files.
+//
+// The Include and Exclude globs are matched against file paths as found, using
+// forward slashes. A glob without a slash matches if any element of the path
+// matches it, e.g. "vendor" matches "docs/vendor/foo.md". Otherwise the glob
+// must match the path, or one of the directories in it, element by element
+// where "**" matches any number of elements, e.g. "docs/**/*.md".
+type WalkOptions struct {
+	// Globs of which a file must match at least one to be kept, if any.
+	Include []string
+
+	// Globs of files and directories to leave out.
+	Exclude []string
+
+	// Extensions of which a file must have one to be kept, if any. The leading
+	// dot is optional.
+	Extensions []string
+
+	// The maximum depth to walk a directory to, where the files directly inside
+	// the directory are at depth 1. There is no limit if it is 0.
+	MaxDepth int
+
+	// The policy for symbolic links found while walking a directory.
+	Symlinks SymlinkPolicy
+
+	// Flag indicating whether hidden and "node_modules" directories are walked.
+	NoDefaultExcludes bool
+
+	// Flag indicating whether .gitignore and .wordrowignore files are disregarded
+	// when walking a directory.
+	NoIgnore bool
+
+	// Flag indicating whether symbolic links in resolved paths are replaced by
+	// their targets, so that a file is found only once even if it is reachable
+	// through different links.
+	ResolveSymlinks bool
+
+	// Function called for every resolved path that is left out because it refers
+	// to the same file as the `original` path, if any.
+	OnDuplicate func(path, original string)
+}
+
+// Get the options to walk directories with in the fs package.
+func (o *WalkOptions) toFS() fs.WalkOptions {
+	symlinks := fs.SymlinksSkip
+	switch o.Symlinks {
+	case SymlinksFollow:
+		symlinks = fs.SymlinksFollow
+	case SymlinksError:
+		symlinks = fs.SymlinksError
+	}
+
+	return fs.WalkOptions{
+		Include:           o.Include,
+		Exclude:           o.Exclude,
+		Extensions:        o.Extensions,
+		MaxDepth:          o.MaxDepth,
+		Symlinks:          symlinks,
+		NoDefaultExcludes: o.NoDefaultExcludes,
+		NoIgnore:          o.NoIgnore,
+		ResolveSymlinks:   o.ResolveSymlinks,
+		OnDuplicate:       o.OnDuplicate,
+	}
+}
+
+// ResolveFiles resolves any number of globs, file paths, and directory paths
+// into distinct file paths, in sorted order. Directories are walked using the
+// `options`, and files matching a glob are kept only if walking the directory
+// the glob starts in would find them. Files specified by their path are always
+// kept. If multiple paths refer to the same file only the first is kept, see
+// WalkOptions.OnDuplicate. The function returns an error for every invalid
+// pattern and every error that occurs while walking a directory.
+func ResolveFiles(options WalkOptions, patterns ...string) ([]string, []error) {
+	return fs.ResolveGlobsWith(options.toFS(), patterns...)
+}
+
+// FileOptions configures how a Replacer updates files, see ReplaceFile and
+// ReplaceFiles. The zero value updates files without syncing them, streams
+// files larger than the DefaultStreamThreshold, and processes as many files in
+// parallel as there are CPUs.
+type FileOptions struct {
+	// Flag indicating whether updated files are synced to the storage device.
+	Fsync bool
+
+	// The size in bytes above which files are streamed, see Replacer.Stream. If
+	// it is 0 the DefaultStreamThreshold is used, if it is negative files are
+	// never streamed. Files are never streamed if Decide or Report is set.
+	StreamThreshold int64
+
+	// The function that decides on every replacement, if any, see Decider.
+	Decide Decider
+
+	// The function that is called before a changed file is written, if any. The
+	// file at `filePath` is left as is if it returns false.
+	Confirm func(filePath string) bool
+
+	// The Report to record the changes to every changed file in, if any.
+	Report *Report
+
+	// The maximum number of files processed in parallel. If it is 0 or less it
+	// defaults to GOMAXPROCS.
+	Jobs int
+
+	// Flag indicating whether no more files are processed after an error.
+	FailFast bool
+}
+
+// Get the size in bytes above which files are streamed as configured by the
+// options, or -1 if files are never streamed.
+func (o *FileOptions) streamThreshold() int64 {
+	if o.Decide != nil || o.Report != nil || o.StreamThreshold < 0 {
+		return -1
+	}
+
+	if o.StreamThreshold == 0 {
+		return DefaultStreamThreshold
+	}
+
+	return o.StreamThreshold
+}
+
+// Get the flag to open files with as configured by the options.
+func (o *FileOptions) openFlag() fs.Flag {
+	if o.Fsync {
+		return fs.OReadWriteSync
+	}
+
+	return fs.OReadWrite
+}
+
+// FileResult is the outcome of updating a single file.
+type FileResult struct {
+	// The path of the file.
+	Path string
+
+	// Flag indicating whether the content of the file was changed.
+	Changed bool
+
+	// The changes made to the content of the file, ordered by their position.
+	Changes []Change
+
+	// The error that occurred while updating the file, if any.
+	Err error
+
+	// The state of the file right after it was changed, or nil if it was not
+	// changed. Unlike the current state of the file, it does not reflect changes
+	// made to the file by others since.
+	Written os.FileInfo
+}
+
+// Update the content of the `file`, named `name`, as configured by the
+// `options`, see ReplaceFile. The content is read into memory at once.
+func (r *Replacer) replaceIn(
+	file fs.ReadWriter,
+	name string,
+	options *FileOptions,
+) FileResult {
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		err = errors.Wrapf(err, "Could not read from file '%s'", name)
+		return FileResult{Path: name, Err: err}
+	}
+
+	var updatedContent []byte
+	var changes []Change
+	if options.Decide != nil {
+		updatedContent, changes = r.ReplaceFunc(content, options.Decide)
+	} else {
+		updatedContent, changes = r.Replace(content)
+	}
+
+	if bytes.Equal(content, updatedContent) {
+		return FileResult{Path: name}
+	}
+
+	if options.Confirm != nil && !options.Confirm(name) {
+		return FileResult{Path: name}
+	}
+
+	if _, err := file.Write(updatedContent); err != nil {
+		err = errors.Wrapf(err, "Could not write to file '%s'", name)
+		return FileResult{Path: name, Err: err}
+	}
+
+	if options.Report != nil {
+		options.Report.Record(name, content, changes)
+	}
+
+	return FileResult{Path: name, Changed: true, Changes: changes}
+}
+
+// Update the content of the `file`, named `name`, as configured by the
+// `options`, see ReplaceFile. The content is streamed, see Stream.
+func (r *Replacer) streamIn(
+	file *fs.File,
+	name string,
+	options *FileOptions,
+) FileResult {
+	var changes []Change
+	err := file.Rewrite(func(w io.Writer) (bool, error) {
+		var err error
+		changes, err = r.Stream(w, file)
+		if err != nil || len(changes) == 0 {
+			return false, err
+		}
+
+		if options.Confirm != nil && !options.Confirm(name) {
+			changes = nil
+		}
+
+		return len(changes) > 0, nil
+	})
+	if err != nil {
+		err = errors.Wrapf(err, "Could not stream file '%s'", name)
+		return FileResult{Path: name, Err: err}
+	}
+
+	return FileResult{Path: name, Changed: len(changes) > 0, Changes: changes}
+}
+
+// Update the content of the `file` at `filePath` as configured by the `options`,
+// see ReplaceFile.
+func (r *Replacer) replaceInFile(
+	file *fs.File,
+	filePath string,
+	options *FileOptions,
+) FileResult {
+	if threshold := options.streamThreshold(); threshold >= 0 {
+		if size, err := file.Size(); err == nil && size > threshold {
+			return r.streamIn(file, filePath, options)
+		}
+	}
+
+	return r.replaceIn(file, filePath, options)
+}
+
+// ReplaceFile replaces words in the file at `filePath`, configured by the
+// `options`. The file is only written if its content changed, in which case it
+// is replaced atomically, unless the permissions of its directory only allow it
+// to be overwritten in place. Files larger than the stream threshold of the
+// `options` are streamed, see Stream, others are read into memory at once.
+//
+// If opening the file fails or a reading or writing error occurs, the Err of
+// the result is set and the file is left as is.
+func (r *Replacer) ReplaceFile(filePath string, options FileOptions) FileResult {
+	handle, err := fs.OpenFile(filePath, options.openFlag())
+	if err != nil {
+		return FileResult{Path: filePath, Err: err}
+	}
+
+	defer handle.Close()
+
+	result := r.replaceInFile(handle, filePath, &options)
+	if result.Changed {
+		result.Written = handle.Written()
+	}
+
+	return result
+}
+
+// ReplaceFiles replaces words in all files at `filePaths`, configured by the
+// `options`, see ReplaceFile. The files are processed in parallel. No more files
+// are processed once `ctx` is cancelled or, if the `options` fail fast, once an
+// error occurred. Files that are being processed at that point are processed in
+// full.
+//
+// It returns the results of all processed files in the order of `filePaths`.
+func (r *Replacer) ReplaceFiles(
+	ctx context.Context,
+	filePaths []string,
+	options FileOptions,
+) []FileResult {
+	return getPool(&options).run(ctx, len(filePaths), func(i int) FileResult {
+		return r.ReplaceFile(filePaths[i], options)
+	})
+}
@@ -0,0 +1,342 @@
+package wordrow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ericcornelissen/stringsx"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+)
+
+// Create a temporary directory with a file named `name` containing `content`.
+// It returns the path of the file and a function to remove the directory.
+func createTempFile(t *testing.T, name, content string) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	cleanup := func() { os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		cleanup()
+		t.Fatalf("Could not create a temporary file (%s)", err)
+	}
+
+	return path, cleanup
+}
+
+func TestWalkOptions(t *testing.T) {
+	policies := map[SymlinkPolicy]fs.SymlinkPolicy{
+		SymlinksSkip:   fs.SymlinksSkip,
+		SymlinksFollow: fs.SymlinksFollow,
+		SymlinksError:  fs.SymlinksError,
+	}
+
+	for policy, expected := range policies {
+		options := WalkOptions{Symlinks: policy, MaxDepth: 3, NoIgnore: true}
+		converted := options.toFS()
+		if converted.Symlinks != expected {
+			t.Errorf("Unexpected policy for %d (got %d)", policy, converted.Symlinks)
+		}
+
+		if converted.MaxDepth != 3 || !converted.NoIgnore {
+			t.Errorf("Unexpected options (got %+v)", converted)
+		}
+	}
+}
+
+func TestFileOptions(t *testing.T) {
+	t.Run("Stream threshold", func(t *testing.T) {
+		decide := func(_ []byte, _ Change) bool { return true }
+		cases := []struct {
+			options  FileOptions
+			expected int64
+		}{
+			{FileOptions{}, DefaultStreamThreshold},
+			{FileOptions{StreamThreshold: 42}, 42},
+			{FileOptions{StreamThreshold: -1}, -1},
+			{FileOptions{StreamThreshold: 42, Decide: decide}, -1},
+			{FileOptions{StreamThreshold: 42, Report: new(Report)}, -1},
+		}
+
+		for i, c := range cases {
+			if threshold := c.options.streamThreshold(); threshold != c.expected {
+				t.Errorf("Unexpected threshold for case %d (got %d)", i, threshold)
+			}
+		}
+	})
+	t.Run("Open flag", func(t *testing.T) {
+		if flag := (&FileOptions{}).openFlag(); flag != fs.OReadWrite {
+			t.Errorf("Unexpected flag (got %s)", flag)
+		}
+
+		if flag := (&FileOptions{Fsync: true}).openFlag(); flag != fs.OReadWriteSync {
+			t.Errorf("Unexpected flag (got %s)", flag)
+		}
+	})
+}
+
+func TestReplaceIn(t *testing.T) {
+	from0, to0 := "hello", "hey"
+	from1, to1 := "world", "planet"
+
+	r, _ := New([]Rule{{From: from0, To: to0}, {From: from1, To: to1}}, Options{})
+
+	process := func(content string, options *FileOptions) (FileResult, string) {
+		t.Helper()
+
+		writer := new(bytes.Buffer)
+		bufferedWriter := bufio.NewWriter(writer)
+		handle := bufio.NewReadWriter(
+			bufio.NewReader(stringsx.NewReader(content)),
+			bufferedWriter,
+		)
+
+		result := r.replaceIn(handle, "foo.txt", options)
+		bufferedWriter.Flush()
+		return result, writer.String()
+	}
+
+	t.Run("Replace something", func(t *testing.T) {
+		content := fmt.Sprintf("%s %s", from0, from1)
+		expectedWritten := fmt.Sprintf("%s %s", to0, to1)
+
+		result, written := process(content, &FileOptions{})
+		if result.Err != nil {
+			t.Fatalf("Unexpected error (%s)", result.Err)
+		}
+
+		if !result.Changed || len(result.Changes) != 2 {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		if written != expectedWritten {
+			t.Errorf("Unexpected value written (got '%s')", written)
+		}
+	})
+	t.Run("Replace nothing", func(t *testing.T) {
+		result, written := process("foobar", &FileOptions{})
+		if result.Err != nil {
+			t.Fatalf("Unexpected error (%s)", result.Err)
+		}
+
+		if result.Changed {
+			t.Error("Expected the file to be reported as unchanged")
+		}
+
+		if written != "" {
+			t.Errorf("Unexpected value written (got '%s')", written)
+		}
+	})
+	t.Run("Decide", func(t *testing.T) {
+		decide := func(_ []byte, c Change) bool {
+			return c.Original == from1
+		}
+
+		content := fmt.Sprintf("%s %s", from0, from1)
+		result, written := process(content, &FileOptions{Decide: decide})
+		if !result.Changed || len(result.Changes) != 1 {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		if expected := fmt.Sprintf("%s %s", from0, to1); written != expected {
+			t.Errorf("Unexpected value written (got '%s')", written)
+		}
+	})
+	t.Run("Not confirmed", func(t *testing.T) {
+		confirm := func(_ string) bool { return false }
+
+		result, written := process(from0, &FileOptions{Confirm: confirm})
+		if result.Changed {
+			t.Error("Expected the file to be reported as unchanged")
+		}
+
+		if written != "" {
+			t.Errorf("Unexpected value written (got '%s')", written)
+		}
+	})
+	t.Run("Report", func(t *testing.T) {
+		report := new(Report)
+
+		process(from0, &FileOptions{Report: report})
+		process("foobar", &FileOptions{Report: report})
+
+		files := report.Files()
+		if len(files) != 1 || len(files[0].Matches) != 1 {
+			t.Errorf("Unexpected report (got %+v)", files)
+		}
+	})
+	t.Run("Reading error", func(t *testing.T) {
+		writer := new(bytes.Buffer)
+		handle := bufio.NewReadWriter(
+			bufio.NewReader(iotest.TimeoutReader(stringsx.NewReader(from0))),
+			bufio.NewWriter(writer),
+		)
+
+		result := r.replaceIn(handle, "foo.txt", &FileOptions{})
+		if result.Err == nil {
+			t.Fatal("Expected an error but got none")
+		}
+
+		if errors.Unwrap(result.Err) != iotest.ErrTimeout {
+			t.Errorf("Expected the cause to be kept (got '%s')", result.Err)
+		}
+
+		if writer.Len() != 0 {
+			t.Errorf("Unexpected value written (got '%s')", writer)
+		}
+	})
+	t.Run("Writing error", func(t *testing.T) {
+		handle := bufio.NewReadWriter(
+			bufio.NewReader(stringsx.NewReader(from0)),
+			bufio.NewWriterSize(errWriter{}, 1),
+		)
+
+		result := r.replaceIn(handle, "foo.txt", &FileOptions{})
+		if result.Err == nil {
+			t.Fatal("Expected an error but got none")
+		}
+	})
+}
+
+func TestReplaceFile(t *testing.T) {
+	r, _ := New([]Rule{{From: "hello", To: "hey"}}, Options{})
+
+	t.Run("Changed file", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "hello.txt", "Hello world!")
+		defer cleanup()
+
+		result := r.ReplaceFile(path, FileOptions{})
+		if result.Err != nil || !result.Changed || result.Path != path {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the file (%s)", err)
+		}
+
+		if string(content) != "Hey world!" {
+			t.Errorf("Unexpected content (got '%s')", content)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		if result.Written == nil || !os.SameFile(result.Written, info) {
+			t.Errorf("Unexpected written state (got %v)", result.Written)
+		}
+	})
+	t.Run("Unchanged file", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "foo.txt", "foobar")
+		defer cleanup()
+
+		before, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		result := r.ReplaceFile(path, FileOptions{})
+		if result.Err != nil || result.Changed || result.Written != nil {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		after, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat the file (%s)", err)
+		}
+
+		if !os.SameFile(before, after) {
+			t.Error("Expected the unchanged file not to be rewritten")
+		}
+	})
+	t.Run("Streamed file", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "hello.txt", "Hello world!")
+		defer cleanup()
+
+		result := r.ReplaceFile(path, FileOptions{StreamThreshold: 4})
+		if result.Err != nil || !result.Changed || len(result.Changes) != 1 {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the file (%s)", err)
+		}
+
+		if string(content) != "Hey world!" {
+			t.Errorf("Unexpected content (got '%s')", content)
+		}
+	})
+	t.Run("Streamed file, not confirmed", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "hello.txt", "Hello world!")
+		defer cleanup()
+
+		options := FileOptions{
+			StreamThreshold: 4,
+			Confirm:         func(_ string) bool { return false },
+		}
+
+		result := r.ReplaceFile(path, options)
+		if result.Err != nil || result.Changed {
+			t.Errorf("Unexpected result (got %+v)", result)
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the file (%s)", err)
+		}
+
+		if string(content) != "Hello world!" {
+			t.Errorf("Unexpected content (got '%s')", content)
+		}
+	})
+	t.Run("Missing file", func(t *testing.T) {
+		result := r.ReplaceFile("this-file-does-not-exist", FileOptions{})
+		if result.Err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
+
+func TestReplaceFiles(t *testing.T) {
+	r, _ := New([]Rule{{From: "hello", To: "hey"}}, Options{})
+
+	changedPath, cleanupChanged := createTempFile(t, "hello.txt", "Hello world!")
+	defer cleanupChanged()
+
+	unchangedPath, cleanupUnchanged := createTempFile(t, "foo.txt", "foobar")
+	defer cleanupUnchanged()
+
+	filePaths := []string{changedPath, "this-file-does-not-exist", unchangedPath}
+	results := r.ReplaceFiles(context.Background(), filePaths, FileOptions{Jobs: 2})
+	if len(results) != len(filePaths) {
+		t.Fatalf("Unexpected number of results (got %d)", len(results))
+	}
+
+	if !results[0].Changed || results[0].Err != nil {
+		t.Errorf("Unexpected first result (got %+v)", results[0])
+	}
+
+	if results[1].Err == nil {
+		t.Errorf("Unexpected second result (got %+v)", results[1])
+	}
+
+	if results[2].Changed || results[2].Err != nil {
+		t.Errorf("Unexpected third result (got %+v)", results[2])
+	}
+}
@@ -0,0 +1,54 @@
+package wordrow
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Match is a Change together with its position in the content of an input.
+type Match struct {
+	Change
+
+	// The line of the Change, starting at 1.
+	Line int
+
+	// The column of the Change, starting at 1 and counted in characters.
+	Column int
+
+	// The line of the end (exclusive) of the Change, starting at 1.
+	EndLine int
+
+	// The column of the end (exclusive) of the Change, starting at 1 and counted
+	// in characters.
+	EndColumn int
+}
+
+// Position gets the line and column of the byte at `offset` in `content`. Both
+// the line and the column start at 1, and the column is counted in characters.
+func Position(content []byte, offset int) (line, column int) {
+	before := content[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+
+	line = bytes.Count(before, []byte{'\n'}) + 1
+	column = utf8.RuneCount(before[lineStart:]) + 1
+	return line, column
+}
+
+// Locate gets the Matches for the `changes` to the `content` of an input, in
+// the same order as the `changes`.
+func Locate(content []byte, changes []Change) []Match {
+	matches := make([]Match, len(changes))
+	for i, change := range changes {
+		line, column := Position(content, change.Start)
+		endLine, endColumn := Position(content, change.End)
+		matches[i] = Match{
+			Change:    change,
+			Line:      line,
+			Column:    column,
+			EndLine:   endLine,
+			EndColumn: endColumn,
+		}
+	}
+
+	return matches
+}
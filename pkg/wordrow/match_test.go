@@ -0,0 +1,58 @@
+package wordrow
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPosition(t *testing.T) {
+	content := []byte("Hello world!\nGrüße, world!\n")
+
+	t.Run("start of content", func(t *testing.T) {
+		line, column := Position(content, 0)
+		if line != 1 || column != 1 {
+			t.Errorf("Unexpected position (got %d:%d)", line, column)
+		}
+	})
+	t.Run("first line", func(t *testing.T) {
+		line, column := Position(content, 6)
+		if line != 1 || column != 7 {
+			t.Errorf("Unexpected position (got %d:%d)", line, column)
+		}
+	})
+	t.Run("start of second line", func(t *testing.T) {
+		line, column := Position(content, 13)
+		if line != 2 || column != 1 {
+			t.Errorf("Unexpected position (got %d:%d)", line, column)
+		}
+	})
+	t.Run("after multi-byte characters", func(t *testing.T) {
+		offset := bytes.LastIndex(content, []byte("world"))
+
+		line, column := Position(content, offset)
+		if line != 2 || column != 8 {
+			t.Errorf("Unexpected position (got %d:%d)", line, column)
+		}
+	})
+}
+
+func TestLocate(t *testing.T) {
+	content := []byte("Hello\nworld!")
+	changes := []Change{
+		{Start: 6, End: 11, Original: "world", Replacement: "planet"},
+		{Start: 0, End: 5, Original: "Hello", Replacement: "Hey"},
+	}
+
+	matches := Locate(content, changes)
+	if len(matches) != len(changes) {
+		t.Fatalf("Unexpected number of matches (got %d)", len(matches))
+	}
+
+	if matches[0].Original != "world" || matches[0].Line != 2 || matches[0].Column != 1 {
+		t.Errorf("Unexpected first match (got %+v)", matches[0])
+	}
+
+	if matches[1].Original != "Hello" || matches[1].EndLine != 1 || matches[1].EndColumn != 6 {
+		t.Errorf("Unexpected second match (got %+v)", matches[1])
+	}
+}
@@ -0,0 +1,128 @@
+package wordrow
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+	"github.com/ericcornelissen/wordrow/internal/mappings"
+	"github.com/ericcornelissen/wordrow/internal/mappings/csv"
+	"github.com/ericcornelissen/wordrow/internal/mappings/json"
+	"github.com/ericcornelissen/wordrow/internal/mappings/markdown"
+	"github.com/ericcornelissen/wordrow/internal/mappings/toml"
+	"github.com/ericcornelissen/wordrow/internal/mappings/yaml"
+)
+
+// The names of the mapping file formats, see ParseRules.
+const (
+	// FormatCSV is the name of the Comma Separated Values (CSV) format.
+	FormatCSV = "csv"
+
+	// FormatJSON is the name of the JSON format.
+	FormatJSON = "json"
+
+	// FormatMarkdown is the name of the MarkDown format.
+	FormatMarkdown = "md"
+
+	// FormatTOML is the name of the TOML format.
+	FormatTOML = "toml"
+
+	// FormatTSV is the name of the Tab Separated Values (TSV) format.
+	FormatTSV = "tsv"
+
+	// FormatYAML is the name of the YAML format.
+	FormatYAML = "yaml"
+)
+
+// FormatDSV gets the name of the Delimiter Separated Values (DSV) format with
+// the `separator`, e.g. "dsv;sep=|". Apart from the separator, the format is the
+// same as the CSV format.
+func FormatDSV(separator rune) string {
+	return fmt.Sprintf("dsv;sep=%c", separator)
+}
+
+// Get the `mappings` and `err` of a parser as rules.
+func toParsed(mappings []common.Mapping, err error) ([]Rule, error) {
+	return toRules(mappings), err
+}
+
+// ParseRules parses the mapping file read from `reader` in the `format` into a
+// list of rules, in the order in which they are defined. The `format` is either
+// the name of a format or a file extension, e.g. "csv" or ".markdown".
+//
+// The function returns an error if the format is unknown or if the content is
+// improperly formatted.
+func ParseRules(reader io.Reader, format string) ([]Rule, error) {
+	return toParsed(mappings.ParseReader(reader, format))
+}
+
+// ParseCSV parses the CSV formatted mapping file read from `reader` into a list
+// of rules, see ParseRules.
+func ParseCSV(reader io.Reader) ([]Rule, error) {
+	return toParsed(csv.Parse(bufio.NewReader(reader)))
+}
+
+// ParseDSV parses the mapping file read from `reader`, with values separated by
+// the `separator`, into a list of rules, see ParseRules and FormatDSV.
+func ParseDSV(reader io.Reader, separator rune) ([]Rule, error) {
+	return toParsed(csv.ParseSeparated(bufio.NewReader(reader), separator))
+}
+
+// ParseJSON parses the JSON formatted mapping file read from `reader` into a
+// list of rules, see ParseRules.
+func ParseJSON(reader io.Reader) ([]Rule, error) {
+	return toParsed(json.Parse(bufio.NewReader(reader)))
+}
+
+// ParseMarkdown parses the MarkDown formatted mapping file read from `reader`
+// into a list of rules, see ParseRules.
+func ParseMarkdown(reader io.Reader) ([]Rule, error) {
+	return toParsed(markdown.Parse(bufio.NewReader(reader)))
+}
+
+// ParseTOML parses the TOML formatted mapping file read from `reader` into a
+// list of rules, see ParseRules.
+func ParseTOML(reader io.Reader) ([]Rule, error) {
+	return toParsed(toml.Parse(bufio.NewReader(reader)))
+}
+
+// ParseTSV parses the TSV formatted mapping file read from `reader` into a list
+// of rules, see ParseRules.
+func ParseTSV(reader io.Reader) ([]Rule, error) {
+	return toParsed(csv.ParseTSV(bufio.NewReader(reader)))
+}
+
+// ParseYAML parses the YAML formatted mapping file read from `reader` into a
+// list of rules, see ParseRules.
+func ParseYAML(reader io.Reader) ([]Rule, error) {
+	return toParsed(yaml.Parse(bufio.NewReader(reader)))
+}
+
+// ParseFile parses the mapping file at `filePath` in the `format` into a list
+// of rules, see ParseRules. If the `format` is empty it is derived from the
+// extension of the file. The Source of every rule is set to `filePath`.
+func ParseFile(filePath, format string) ([]Rule, error) {
+	if format == "" {
+		format = fs.GetExt(filePath)
+	}
+
+	handle, err := fs.OpenFile(filePath, fs.OReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	defer handle.Close()
+
+	rules, err := ParseRules(handle, format)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		rules[i].Source = filePath
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,168 @@
+package wordrow
+
+import (
+	"testing"
+
+	"github.com/ericcornelissen/stringsx"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		rules, err := ParseRules(stringsx.NewReader("foo,bar"), FormatCSV)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if len(rules) != 1 || rules[0].From != "foo" || rules[0].To != "bar" {
+			t.Errorf("Unexpected rules (got %+v)", rules)
+		}
+	})
+	t.Run("File extension", func(t *testing.T) {
+		rules, err := ParseRules(stringsx.NewReader("| from | to |\n| --- | --- |\n| foo | bar |"), ".md")
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if len(rules) != 1 || rules[0].From != "foo" || rules[0].To != "bar" {
+			t.Errorf("Unexpected rules (got %+v)", rules)
+		}
+	})
+	t.Run("DSV", func(t *testing.T) {
+		rules, err := ParseRules(stringsx.NewReader("foo, bar|baz"), FormatDSV('|'))
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if len(rules) != 1 || rules[0].From != "foo, bar" || rules[0].To != "baz" {
+			t.Errorf("Unexpected rules (got %+v)", rules)
+		}
+	})
+	t.Run("Unknown format", func(t *testing.T) {
+		if _, err := ParseRules(stringsx.NewReader("foo,bar"), "bar"); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+	t.Run("Incorrect format", func(t *testing.T) {
+		if _, err := ParseRules(stringsx.NewReader("foobar"), FormatCSV); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
+
+func TestParseCSV(t *testing.T) {
+	rules, err := ParseCSV(stringsx.NewReader("foo,bar\nhello,hey"))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hello" || rules[1].To != "hey" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseDSV(t *testing.T) {
+	rules, err := ParseDSV(stringsx.NewReader("foo;bar\nhello;hey"), ';')
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hello" || rules[1].To != "hey" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+
+	for _, separator := range []rune{'"', '\n'} {
+		_, err := ParseDSV(stringsx.NewReader("foo;bar"), separator)
+		if err == nil || !stringsx.Contains(err.Error(), "Invalid separator") {
+			t.Errorf("Unexpected error for %q (got '%v')", separator, err)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	rules, err := ParseJSON(stringsx.NewReader(`[{"from": ["foo", "hi"], "to": "bar"}]`))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hi" || rules[1].To != "bar" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	rules, err := ParseMarkdown(stringsx.NewReader("| from | to |\n| --- | --- |\n| foo | bar |\n| hello | hey |"))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hello" || rules[1].To != "hey" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	rules, err := ParseTOML(stringsx.NewReader("[[rule]]\nfrom = [\"foo\", \"hi\"]\nto = \"bar\""))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hi" || rules[1].To != "bar" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseTSV(t *testing.T) {
+	rules, err := ParseTSV(stringsx.NewReader("foo, bar\tbaz\nhello\they"))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[0].From != "foo, bar" || rules[1].To != "hey" {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	rules, err := ParseYAML(stringsx.NewReader("- from: [foo, hi]\n  to: bar\n  case-sensitive: true"))
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err)
+	}
+
+	if len(rules) != 2 || rules[1].From != "hi" || rules[1].To != "bar" || !rules[1].CaseSensitive {
+		t.Errorf("Unexpected rules (got %+v)", rules)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	t.Run("Format from extension", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "map.csv", "foo,bar")
+		defer cleanup()
+
+		rules, err := ParseFile(path, "")
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if len(rules) != 1 || rules[0].Source != path {
+			t.Errorf("Unexpected rules (got %+v)", rules)
+		}
+	})
+	t.Run("Explicit format", func(t *testing.T) {
+		path, cleanup := createTempFile(t, "map.txt", "foo,bar")
+		defer cleanup()
+
+		rules, err := ParseFile(path, FormatCSV)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if len(rules) != 1 || rules[0].From != "foo" {
+			t.Errorf("Unexpected rules (got %+v)", rules)
+		}
+	})
+	t.Run("Missing file", func(t *testing.T) {
+		if _, err := ParseFile("this-file-does-not-exist.csv", ""); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
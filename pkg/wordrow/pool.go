@@ -0,0 +1,86 @@
+package wordrow
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// The pool type represents a bounded pool of workers that process inputs in
+// parallel.
+type pool struct {
+	// The maximum number of inputs that are processed at the same time.
+	jobs int
+
+	// Flag indicating whether no more inputs are processed after an error.
+	failFast bool
+}
+
+// Get the pool to process files with as configured by the `options`. If the
+// number of jobs is not configured it defaults to GOMAXPROCS.
+func getPool(options *FileOptions) pool {
+	jobs := options.Jobs
+	if jobs < 1 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	return pool{jobs: jobs, failFast: options.FailFast}
+}
+
+// Process the inputs 0 up to `n` using the `process` function, with at most as
+// many inputs being processed at the same time as the pool has jobs. No more
+// inputs are processed once `ctx` is cancelled or, if the pool fails fast, once
+// processing an input resulted in an error. Inputs that are being processed at
+// that point are processed in full.
+//
+// It returns the results of all processed inputs in the order of the inputs,
+// regardless of the order in which they were processed.
+func (p pool) run(
+	ctx context.Context,
+	n int,
+	process func(i int) FileResult,
+) []FileResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]FileResult, n)
+	processed := make([]bool, n)
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.jobs && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				results[i], processed[i] = process(i), true
+				if results[i].Err != nil && p.failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+		}
+	}
+
+	close(indices)
+	wg.Wait()
+
+	var ordered []FileResult
+	for i, r := range results {
+		if processed[i] {
+			ordered = append(ordered, r)
+		}
+	}
+
+	return ordered
+}
@@ -0,0 +1,174 @@
+package wordrow
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ericcornelissen/wordrow/internal/errors"
+	"github.com/ericcornelissen/wordrow/internal/fs"
+)
+
+func TestGetPool(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		p := getPool(&FileOptions{})
+		if p.jobs != runtime.GOMAXPROCS(0) || p.failFast {
+			t.Errorf("Unexpected pool (got %+v)", p)
+		}
+	})
+	t.Run("Configured", func(t *testing.T) {
+		p := getPool(&FileOptions{Jobs: 3, FailFast: true})
+		if p.jobs != 3 || !p.failFast {
+			t.Errorf("Unexpected pool (got %+v)", p)
+		}
+	})
+}
+
+func TestPoolRun(t *testing.T) {
+	t.Run("Bounded", func(t *testing.T) {
+		var active, maxActive int32
+		process := func(i int) FileResult {
+			n := atomic.AddInt32(&active, 1)
+			for {
+				max := atomic.LoadInt32(&maxActive)
+				if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			return FileResult{Changed: i%2 == 0}
+		}
+
+		results := pool{jobs: 3}.run(context.Background(), 50, process)
+		if len(results) != 50 {
+			t.Fatalf("Unexpected number of results (got %d)", len(results))
+		}
+
+		for i, r := range results {
+			if r.Changed != (i%2 == 0) {
+				t.Errorf("Unexpected result at %d (got %+v)", i, r)
+			}
+		}
+
+		if maxActive > 3 {
+			t.Errorf("Too many inputs processed at the same time (got %d)", maxActive)
+		}
+	})
+	t.Run("Fail fast", func(t *testing.T) {
+		process := func(i int) FileResult {
+			if i == 2 {
+				return FileResult{Err: errors.New("Something went wrong")}
+			}
+
+			return FileResult{}
+		}
+
+		results := pool{jobs: 1, failFast: true}.run(context.Background(), 10, process)
+		if len(results) != 3 || results[2].Err == nil {
+			t.Errorf("Unexpected results (got %+v)", results)
+		}
+	})
+	t.Run("Don't fail fast", func(t *testing.T) {
+		process := func(i int) FileResult {
+			return FileResult{Err: errors.Newf("Error %d", i)}
+		}
+
+		results := pool{jobs: 4}.run(context.Background(), 10, process)
+		if len(results) != 10 {
+			t.Errorf("Unexpected number of results (got %d)", len(results))
+		}
+	})
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := pool{jobs: 4}.run(ctx, 10, func(i int) FileResult {
+			return FileResult{}
+		})
+		if len(results) != 0 {
+			t.Errorf("Unexpected number of results (got %d)", len(results))
+		}
+	})
+}
+
+func TestReplaceFilesStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping stress test in short mode")
+	}
+
+	dir, err := ioutil.TempDir("", "wordrow")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory (%s)", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	const fileCount = 5000
+	var filePaths, missingPaths []string
+	for i := 0; i < fileCount; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file-%d.txt", i))
+		if i%500 == 0 {
+			missingPaths = append(missingPaths, path)
+		} else if err := ioutil.WriteFile(path, []byte("Hello world!"), 0644); err != nil {
+			t.Fatalf("Could not create a temporary file (%s)", err)
+		}
+
+		filePaths = append(filePaths, path)
+	}
+
+	r, _ := New([]Rule{{From: "hello", To: "hey"}}, Options{})
+	results := r.ReplaceFiles(context.Background(), filePaths, FileOptions{Jobs: 64})
+	if len(results) != fileCount {
+		t.Fatalf("Unexpected number of results (got %d)", len(results))
+	}
+
+	var changed int
+	var errs []error
+	for i, result := range results {
+		if result.Path != filePaths[i] {
+			t.Errorf("Unexpected result at %d (got '%s')", i, result.Path)
+		}
+
+		if result.Changed {
+			changed++
+		}
+
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	if changed != fileCount-len(missingPaths) {
+		t.Errorf("Unexpected number of changed files (got %d)", changed)
+	}
+
+	if len(errs) != len(missingPaths) {
+		t.Fatalf("Unexpected number of errors (got %d)", len(errs))
+	}
+
+	for i, err := range errs {
+		expected := fmt.Sprintf("Could not open '%s' (%s mode)", missingPaths[i], fs.OReadWrite)
+		if err.Error() != expected {
+			t.Errorf("Unexpected error at %d (got '%s')", i, err)
+		}
+	}
+
+	for _, path := range filePaths[1:10] {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the file (%s)", err)
+		}
+
+		if string(content) != "Hey world!" {
+			t.Errorf("Unexpected content of '%s' (got '%s')", path, content)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package wordrow
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/ericcornelissen/wordrow/internal/common"
+	"github.com/ericcornelissen/wordrow/internal/replace"
+	"golang.org/x/text/transform"
+)
+
+// Change is a single replacement made by a Replacer. The position of a Change
+// is given in terms of the original input, i.e. the input before any
+// replacement was made.
+type Change struct {
+	// The Rule that caused the Change.
+	Rule Rule
+
+	// The starting index of the replaced phrase in the original input.
+	Start int
+
+	// The ending index (exclusive) of the replaced phrase in the original input.
+	End int
+
+	// The replaced phrase of the original input.
+	Original string
+
+	// The phrase that replaced the phrase of the original input.
+	Replacement string
+}
+
+// Convert the Change `c` of the replace package into a Change.
+func toChange(c replace.Change) Change {
+	return Change{
+		Rule:        Rule(c.Mapping),
+		Start:       c.Start,
+		End:         c.End,
+		Original:    c.Original,
+		Replacement: c.Replacement,
+	}
+}
+
+// Convert the Changes `cs` of the replace package into Changes.
+func toChanges(cs []replace.Change) []Change {
+	if cs == nil {
+		return nil
+	}
+
+	changes := make([]Change, len(cs))
+	for i, c := range cs {
+		changes[i] = toChange(c)
+	}
+
+	return changes
+}
+
+// Decider is a function that decides whether a replacement is made. The
+// replacement is described by the Change `c` in terms of the original input
+// `s`, like the Changes returned by ReplaceFunc. If other replacements have been
+// made before, the Change covers the original text they replaced.
+type Decider func(s []byte, c Change) bool
+
+// Get the Decider `d` as a Decider of the replace package.
+func (d Decider) toReplaceDecider() replace.Decider {
+	return func(s []byte, c replace.Change) bool {
+		return d(s, toChange(c))
+	}
+}
+
+// Options configures how a Replacer applies its rules.
+type Options struct {
+	// Flag indicating whether the rules are applied all at once rather than one
+	// after the other. If so, the output of one rule is never the input for
+	// another.
+	Simultaneous bool
+
+	// Flag indicating whether the rules are inverted before they are compiled,
+	// see InvertRules.
+	Invert bool
+}
+
+// Replacer is a compiled list of rules that can be used to replace words in any
+// number of inputs. A Replacer is safe for concurrent use.
+type Replacer struct {
+	// The compiled rules.
+	replacer *replace.Replacer
+
+	// The options of the Replacer.
+	options Options
+}
+
+// New compiles the `rules` into a Replacer configured by the `options`. Rules
+// that are invalid, e.g. because their From or To value is empty, are omitted
+// from the Replacer.
+//
+// The function returns an error for every rule that is omitted.
+func New(rules []Rule, options Options) (*Replacer, []error) {
+	if options.Invert {
+		rules = InvertRules(rules)
+	}
+
+	var errs []error
+	mappings := make([]common.Mapping, 0, len(rules))
+	for _, rule := range rules {
+		mapping := common.Mapping(rule)
+		if err := replace.ValidateMapping(mapping); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	r := &Replacer{
+		replacer: replace.New(mappings),
+		options:  options,
+	}
+
+	return r, errs
+}
+
+// Replace replaces words in `s` according to the rules of the Replacer. It
+// returns the updated `s` as well as the Changes made to `s`, ordered by their
+// position in `s`.
+func (r *Replacer) Replace(s []byte) ([]byte, []Change) {
+	var changes []replace.Change
+	if r.options.Simultaneous {
+		s, changes = r.replacer.AllSimultaneousChanges(s)
+	} else {
+		s, changes = r.replacer.AllChanges(s)
+	}
+
+	return s, toChanges(changes)
+}
+
+// ReplaceFunc is like Replace but only makes the replacements for which `decide`
+// returns true.
+func (r *Replacer) ReplaceFunc(s []byte, decide Decider) ([]byte, []Change) {
+	var changes []replace.Change
+	if r.options.Simultaneous {
+		s, changes = r.replacer.AllSimultaneousFunc(s, decide.toReplaceDecider())
+	} else {
+		s, changes = r.replacer.AllFunc(s, decide.toReplaceDecider())
+	}
+
+	return s, toChanges(changes)
+}
+
+// Stream replaces words in the content read from `src`, like Replace, and
+// writes the updated content to `dst`. Unlike Replace, the content is never
+// held in memory all at once.
+//
+// The content is processed in overlapping parts. The overlap, or window, is
+// sized to the longest possible match of any rule, with a margin of about a
+// kilobyte for prefixes, suffixes, and additional whitespace in phrases. Hence,
+// a phrase is replaced even if it spans two parts, unless its match is longer
+// than the window. That can only happen if it contains a very long run of
+// whitespace. Parts are never split inside a word, a part is extended instead.
+func (r *Replacer) Stream(dst io.Writer, src io.Reader) ([]Change, error) {
+	var changes []replace.Change
+	var err error
+	if r.options.Simultaneous {
+		changes, err = r.replacer.StreamSimultaneous(dst, src)
+	} else {
+		changes, err = r.replacer.Stream(dst, src)
+	}
+
+	return toChanges(changes), err
+}
+
+// Transformer gets a transform.Transformer that replaces words according to the
+// rules of the Replacer, like Stream. It can be chained with other transformers,
+// e.g. to decode or normalize the input first, and used with transform.NewReader
+// and transform.NewWriter.
+//
+// Like Stream, the Transformer processes its input in overlapping parts, and it
+// reports transform.ErrShortSrc until it is given more than the window of the
+// Replacer. The input buffer used with the Transformer must therefore be larger
+// than the window, as is the case for the buffers of transform.Reader and
+// transform.Writer for any rule of up to a few hundred characters. The input is
+// never split inside a word, so a word longer than the input buffer results in
+// transform.ErrShortSrc as well.
+func (r *Replacer) Transformer() transform.Transformer {
+	if r.options.Simultaneous {
+		return r.replacer.SimultaneousTransformer()
+	}
+
+	return r.replacer.Transformer()
+}
+
+// ReplaceReader replaces words in the content read from `src` and writes the
+// updated content to `dst`. If the content is at most `threshold` bytes it is
+// replaced as a whole, see Replace, otherwise it is streamed, see Stream. If
+// `threshold` is negative the content is never streamed.
+func (r *Replacer) ReplaceReader(
+	dst io.Writer,
+	src io.Reader,
+	threshold int64,
+) ([]Change, error) {
+	var reader io.Reader = src
+	if threshold >= 0 {
+		reader = io.LimitReader(src, threshold+1)
+	}
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if threshold >= 0 && int64(len(content)) > threshold {
+		return r.Stream(dst, io.MultiReader(bytes.NewReader(content), src))
+	}
+
+	updatedContent, changes := r.Replace(content)
+	if _, err := dst.Write(updatedContent); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
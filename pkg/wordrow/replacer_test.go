@@ -0,0 +1,170 @@
+package wordrow
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+	"unicode"
+
+	"github.com/ericcornelissen/stringsx"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+// The errWriter type is an io.Writer that fails every write.
+type errWriter struct{}
+
+func (w errWriter) Write(data []byte) (int, error) {
+	return 0, iotest.ErrTimeout
+}
+
+func TestReplacer(t *testing.T) {
+	rules := []Rule{
+		{From: "dog", To: "cat"},
+		{From: "cat", To: "dog"},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		r, _ := New(rules, Options{})
+
+		fixed, changes := r.Replace([]byte("dog cat"))
+		if string(fixed) != "dog dog" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+
+		if len(changes) != 2 {
+			t.Errorf("Unexpected number of changes (got %d)", len(changes))
+		}
+	})
+	t.Run("Simultaneous", func(t *testing.T) {
+		r, _ := New(rules, Options{Simultaneous: true})
+
+		fixed, changes := r.Replace([]byte("dog cat"))
+		if string(fixed) != "cat dog" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+
+		if len(changes) != 2 {
+			t.Errorf("Unexpected number of changes (got %d)", len(changes))
+		}
+	})
+	t.Run("Invert", func(t *testing.T) {
+		r, _ := New([]Rule{{From: "hello", To: "hey"}}, Options{Invert: true})
+
+		fixed, _ := r.Replace([]byte("hey world"))
+		if string(fixed) != "hello world" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+	})
+	t.Run("Decide", func(t *testing.T) {
+		r, _ := New(rules, Options{Simultaneous: true})
+		decide := func(_ []byte, c Change) bool {
+			return c.Start > 0
+		}
+
+		fixed, changes := r.ReplaceFunc([]byte("dog cat"), decide)
+		if string(fixed) != "dog dog" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+
+		if len(changes) != 1 {
+			t.Fatalf("Unexpected number of changes (got %d)", len(changes))
+		}
+
+		if changes[0].Rule.From != "cat" || changes[0].Original != "cat" {
+			t.Errorf("Unexpected change (got %+v)", changes[0])
+		}
+	})
+	t.Run("Invalid rules", func(t *testing.T) {
+		invalid := []Rule{{From: "cat", To: " "}, {From: "dog", To: "cat"}, {From: "", To: "horse"}}
+
+		r, errs := New(invalid, Options{})
+		if len(errs) != 2 {
+			t.Errorf("Unexpected number of errors (got %v)", errs)
+		}
+
+		fixed, _ := r.Replace([]byte("dog cat"))
+		if string(fixed) != "cat cat" {
+			t.Errorf("Unexpected result (got '%s')", fixed)
+		}
+	})
+	t.Run("Stream", func(t *testing.T) {
+		r, _ := New(rules, Options{Simultaneous: true})
+
+		var bb bytes.Buffer
+		if _, err := r.Stream(&bb, stringsx.NewReader("dog cat")); err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if bb.String() != "cat dog" {
+			t.Errorf("Unexpected result (got '%s')", bb.String())
+		}
+	})
+}
+
+func TestTransformer(t *testing.T) {
+	r, _ := New([]Rule{{From: "hello world", To: "hey planet"}}, Options{})
+
+	t.Run("Reader", func(t *testing.T) {
+		content := stringsx.Repeat("Hello world!\n", 1000)
+		expected := stringsx.Repeat("Hey planet!\n", 1000)
+
+		reader := transform.NewReader(stringsx.NewReader(content), r.Transformer())
+		out, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if string(out) != expected {
+			t.Error("Unexpected output")
+		}
+	})
+	t.Run("Chained", func(t *testing.T) {
+		chain := transform.Chain(runes.Remove(runes.In(unicode.Cf)), r.Transformer())
+
+		out, _, err := transform.String(chain, "hello\u200b world")
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err)
+		}
+
+		if out != "hey planet" {
+			t.Errorf("Unexpected output (got '%s')", out)
+		}
+	})
+}
+
+func TestReplaceReader(t *testing.T) {
+	r, _ := New([]Rule{{From: "hello world", To: "hey planet"}}, Options{})
+	content := "Hello world!\nHello\nworld!\n"
+	expected := "Hey planet!\nHey\nplanet!\n"
+
+	for _, threshold := range []int64{-1, 0, 4, int64(len(content))} {
+		var bb bytes.Buffer
+		changes, err := r.ReplaceReader(&bb, stringsx.NewReader(content), threshold)
+		if err != nil {
+			t.Fatalf("Unexpected error for threshold %d (%s)", threshold, err)
+		}
+
+		if bb.String() != expected {
+			t.Errorf("Unexpected result for threshold %d (got %q)", threshold, bb.String())
+		}
+
+		if len(changes) != 2 {
+			t.Errorf("Unexpected number of changes for threshold %d (got %d)", threshold, len(changes))
+		}
+	}
+
+	t.Run("Reading error", func(t *testing.T) {
+		reader := iotest.TimeoutReader(stringsx.NewReader(content))
+		if _, err := r.ReplaceReader(new(bytes.Buffer), reader, -1); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+	t.Run("Writing error", func(t *testing.T) {
+		reader := stringsx.NewReader(content)
+		if _, err := r.ReplaceReader(errWriter{}, reader, -1); err == nil {
+			t.Error("Expected an error but got none")
+		}
+	})
+}
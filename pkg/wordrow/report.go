@@ -0,0 +1,50 @@
+package wordrow
+
+import (
+	"sort"
+	"sync"
+)
+
+// FileReport is the report on all Matches in a single input.
+type FileReport struct {
+	// The name of the input.
+	Name string
+
+	// The Matches in the input, in order.
+	Matches []Match
+}
+
+// Report collects the Matches in any number of inputs so that they can be
+// reported on once all inputs have been processed. The zero value is an empty
+// Report. It is safe to use a Report from multiple goroutines.
+type Report struct {
+	// Mutex guarding the files.
+	mu sync.Mutex
+
+	// The reports on the inputs, in the order in which they were recorded.
+	files []FileReport
+}
+
+// Record records the `changes` to the `content` of the input `name`, see
+// Locate.
+func (r *Report) Record(name string, content []byte, changes []Change) {
+	file := FileReport{Name: name, Matches: Locate(content, changes)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.files = append(r.files, file)
+}
+
+// Files gets the reports on all recorded inputs sorted by their name.
+func (r *Report) Files() []FileReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files := append([]FileReport(nil), r.files...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	return files
+}
@@ -0,0 +1,64 @@
+package wordrow
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	content := []byte("Hello world!\nHello planet!\n")
+	changes := []Change{
+		{Start: 0, End: 5, Original: "Hello", Replacement: "Hey"},
+		{Start: 13, End: 18, Original: "Hello", Replacement: "Hey"},
+	}
+
+	t.Run("Positions", func(t *testing.T) {
+		r := new(Report)
+		r.Record("foo.txt", content, changes)
+
+		files := r.Files()
+		if len(files) != 1 {
+			t.Fatalf("Unexpected number of files (got %d)", len(files))
+		}
+
+		expected := [][4]int{{1, 1, 1, 6}, {2, 1, 2, 6}}
+		for i, m := range files[0].Matches {
+			if m.Line != expected[i][0] || m.Column != expected[i][1] {
+				t.Errorf("Unexpected position of match %d (got %d:%d)", i, m.Line, m.Column)
+			}
+
+			if m.EndLine != expected[i][2] || m.EndColumn != expected[i][3] {
+				t.Errorf("Unexpected end of match %d (got %d:%d)", i, m.EndLine, m.EndColumn)
+			}
+		}
+	})
+	t.Run("Sorted", func(t *testing.T) {
+		r := new(Report)
+
+		var wg sync.WaitGroup
+		for _, name := range []string{"c.txt", "a.txt", "b.txt"} {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				r.Record(name, content, changes)
+			}(name)
+		}
+
+		wg.Wait()
+
+		files := r.Files()
+		for i, expected := range []string{"a.txt", "b.txt", "c.txt"} {
+			if files[i].Name != expected {
+				t.Errorf("Unexpected file at %d (got '%s')", i, files[i].Name)
+			}
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		r := new(Report)
+
+		files := r.Files()
+		if len(files) != 0 {
+			t.Errorf("Unexpected number of files (got %d)", len(files))
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package wordrow
+
+import (
+	"github.com/ericcornelissen/wordrow/internal/common"
+)
+
+// Rule is a single rule to replace one phrase `From` by another `To`.
+type Rule struct {
+	// The phrase to be replaced. It may use the *wordrow* syntax for prefixes
+	// and suffixes, see the documentation on mapping files.
+	From string
+
+	// The phrase to replace `From` with.
+	To string
+
+	// The source the Rule is defined in, e.g. the path of a mapping file. It is
+	// empty if the source is unknown.
+	Source string
+
+	// The line in the Source at which the Rule is defined, starting at 1. It is
+	// zero if the line is unknown.
+	Line int
+
+	// Whether `From` is matched case sensitively. If so, `To` is used as is
+	// instead of taking on the capitalization of the phrase it replaces.
+	CaseSensitive bool
+
+	// A description of the Rule, e.g. the reason for the replacement. It is
+	// empty if the Rule has no description.
+	Description string
+
+	// The severity of the Rule, one of "error", "warning", or "info". It is
+	// empty if the Rule has no severity.
+	Severity string
+}
+
+// Convert the `mappings` into rules.
+func toRules(mappings []common.Mapping) []Rule {
+	if mappings == nil {
+		return nil
+	}
+
+	rules := make([]Rule, len(mappings))
+	for i, mapping := range mappings {
+		rules[i] = Rule(mapping)
+	}
+
+	return rules
+}
+
+// Convert the `rules` into mappings.
+func toMappings(rules []Rule) []common.Mapping {
+	if rules == nil {
+		return nil
+	}
+
+	mappings := make([]common.Mapping, len(rules))
+	for i, rule := range rules {
+		mappings[i] = common.Mapping(rule)
+	}
+
+	return mappings
+}
+
+// MergeRules merges the rules `target` and `other` into `target`. A From value
+// present in both `target` and `other` keeps its position in `target` but will
+// end up with the value of `other`. Other rules of `other` are appended to
+// `target` in order.
+func MergeRules(target, other []Rule) []Rule {
+	return toRules(common.MergeMappings(toMappings(target), toMappings(other)))
+}
+
+// InvertRules inverts the `rules`. I.e. it swaps the From and To value of each
+// rule. The order of the rules is maintained.
+func InvertRules(rules []Rule) []Rule {
+	var inverted common.MappingList
+	for _, rule := range rules {
+		rule.From, rule.To = rule.To, rule.From
+		inverted.Set(common.Mapping(rule))
+	}
+
+	return toRules(inverted.Mappings())
+}
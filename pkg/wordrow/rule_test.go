@@ -0,0 +1,103 @@
+package wordrow
+
+import (
+	"testing"
+)
+
+func TestMergeRules(t *testing.T) {
+	target := []Rule{{From: "foo", To: "bar"}, {From: "hello", To: "world"}}
+	other := []Rule{{From: "hello", To: "planet"}, {From: "cat", To: "dog"}}
+
+	result := MergeRules(target, other)
+	if len(result) != 3 {
+		t.Fatalf("Unexpected number of rules (got %d)", len(result))
+	}
+
+	if result[1].From != "hello" || result[1].To != "planet" {
+		t.Errorf("Unexpected second rule (got '%s' to '%s')", result[1].From, result[1].To)
+	}
+
+	if result[2].From != "cat" || result[2].To != "dog" {
+		t.Errorf("Unexpected third rule (got '%s' to '%s')", result[2].From, result[2].To)
+	}
+}
+
+func TestInvertRules(t *testing.T) {
+	t.Run("empty mapping", func(t *testing.T) {
+		var mapping []Rule
+
+		result := InvertRules(mapping)
+		if len(result) != 0 {
+			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
+		}
+	})
+	t.Run("inverts the map", func(t *testing.T) {
+		from0, to0 := "foo", "bar"
+		from1, to1 := "hello", "world"
+
+		mapping := []Rule{
+			{From: from0, To: to0},
+			{From: from1, To: to1},
+		}
+
+		result := InvertRules(mapping)
+		if len(result) != len(mapping) {
+			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
+		}
+
+		if result[0].From != to0 || result[0].To != from0 {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", result[0].From, result[0].To)
+		}
+
+		if result[1].From != to1 || result[1].To != from1 {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", result[1].From, result[1].To)
+		}
+	})
+	t.Run("works with mirrored mapping", func(t *testing.T) {
+		from0, to0 := "foo", "bar"
+		from1, to1 := "bar", "foo"
+
+		mapping := []Rule{
+			{From: from0, To: to0},
+			{From: from1, To: to1},
+		}
+
+		result := InvertRules(mapping)
+		if len(result) != len(mapping) {
+			t.Errorf("Unexpected size of inverted map (got %d)", len(result))
+		}
+
+		if result[0].From != to0 || result[0].To != from0 {
+			t.Errorf("Unexpected first mapping (got '%s' to '%s')", result[0].From, result[0].To)
+		}
+
+		if result[1].From != to1 || result[1].To != from1 {
+			t.Errorf("Unexpected second mapping (got '%s' to '%s')", result[1].From, result[1].To)
+		}
+	})
+	t.Run("many-to-one mapping", func(t *testing.T) {
+		mapping := []Rule{
+			{From: "foo", To: "bar"},
+			{From: "baz", To: "bar"},
+		}
+
+		result := InvertRules(mapping)
+		if len(result) != 1 {
+			t.Fatalf("Unexpected size of inverted map (got %d)", len(result))
+		}
+
+		if result[0].From != "bar" || result[0].To != "baz" {
+			t.Errorf("Unexpected mapping (got '%s' to '%s')", result[0].From, result[0].To)
+		}
+	})
+	t.Run("keeps the source", func(t *testing.T) {
+		mapping := []Rule{
+			{From: "foo", To: "bar", Source: "map.csv", Line: 3},
+		}
+
+		result := InvertRules(mapping)
+		if result[0].Source != "map.csv" || result[0].Line != 3 {
+			t.Errorf("Unexpected source (got '%s' line %d)", result[0].Source, result[0].Line)
+		}
+	})
+}